@@ -0,0 +1,103 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var iecUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+var siUnits = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB"}
+
+// ByteSize returns a human-friendly size with IEC units (1024 based,
+// 1.5 MiB) or SI units (1000 based, 1.6 MB) if si is true and the
+// number of digits after the decimal point given by precision.
+// Trailing zeros (and the decimal point) are dropped so that whole
+// numbers are shown as such. Sizes less than the base are always shown
+// in bytes with no decimal.
+func ByteSize(n uint64, si bool, precision int) string {
+	units, base := iecUnits, 1024.0
+	if si {
+		units, base = siUnits, 1000.0
+	}
+	if float64(n) < base {
+		return fmt.Sprintf("%v %v", n, units[0])
+	}
+	size := float64(n)
+	var u int
+	for size >= base && u < len(units)-1 {
+		size /= base
+		u++
+	}
+	num := strconv.FormatFloat(size, 'f', precision, 64)
+	// rounding up can reach the next unit (1023.99 KiB becomes 1024 KiB)
+	if v, _ := strconv.ParseFloat(num, 64); v >= base && u < len(units)-1 {
+		u++
+		num = strconv.FormatFloat(size/base, 'f', precision, 64)
+	}
+	if strings.Contains(num, ".") {
+		num = strings.TrimRight(strings.TrimRight(num, "0"), ".")
+	}
+	return num + " " + units[u]
+}
+
+// byteUnits maps every lower case unit (and common aliases) to its
+// multiplier.
+var byteUnits = map[string]float64{
+	"": 1, "b": 1, "byte": 1, "bytes": 1,
+	"k": 1 << 10, "ki": 1 << 10, "kib": 1 << 10, "kb": 1e3,
+	"m": 1 << 20, "mi": 1 << 20, "mib": 1 << 20, "mb": 1e6,
+	"g": 1 << 30, "gi": 1 << 30, "gib": 1 << 30, "gb": 1e9,
+	"t": 1 << 40, "ti": 1 << 40, "tib": 1 << 40, "tb": 1e12,
+	"p": 1 << 50, "pi": 1 << 50, "pib": 1 << 50, "pb": 1e15,
+	"e": 1 << 60, "ei": 1 << 60, "eib": 1 << 60, "eb": 1e18,
+}
+
+// ParseByteSize returns the number of bytes from a size string such as
+// 10MiB, 1.5 GB, 512k, or 42. Both IEC (KiB, MiB, ...) and SI (kB,
+// MB, ...) units are accepted without regard to case with optional
+// space between the number and the unit. Single letter units (k, M, G)
+// and those ending in "i" are IEC (1024 based) as is common with
+// command line tools. Fractional values are rounded down to the nearest
+// whole byte. Returns an error if the string cannot be parsed, is
+// negative, or overflows a uint64.
+func ParseByteSize(in string) (uint64, error) {
+	s := strings.TrimSpace(in)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= '0' && r <= '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.TrimSpace(s[i:])
+	if num == "" {
+		return 0, fmt.Errorf("invalid byte size: %q", in)
+	}
+	mult, has := byteUnits[strings.ToLower(unit)]
+	if !has {
+		return 0, fmt.Errorf("invalid byte size unit: %q", unit)
+	}
+	if !strings.Contains(num, ".") {
+		n, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid byte size: %q: %w", in, err)
+		}
+		if mult > 1 && n > math.MaxUint64/uint64(mult) {
+			return 0, fmt.Errorf("byte size overflows uint64: %q", in)
+		}
+		return n * uint64(mult), nil
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size: %q: %w", in, err)
+	}
+	f *= mult
+	if f >= math.MaxUint64 {
+		return 0, fmt.Errorf("byte size overflows uint64: %q", in)
+	}
+	return uint64(f), nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleByteSize() {
+	fmt.Println(to.ByteSize(512, false, 1))
+	fmt.Println(to.ByteSize(1536, false, 1))
+	fmt.Println(to.ByteSize(1572864, false, 2))
+	fmt.Println(to.ByteSize(1572864, true, 2))
+	fmt.Println(to.ByteSize(1048576, false, 2))
+	fmt.Println(to.ByteSize(1048575, false, 1))
+	fmt.Println(to.ByteSize(2300000000, true, 1))
+	fmt.Println(to.ByteSize(18446744073709551615, false, 3))
+	// Output:
+	// 512 B
+	// 1.5 KiB
+	// 1.5 MiB
+	// 1.57 MB
+	// 1 MiB
+	// 1 MiB
+	// 2.3 GB
+	// 16 EiB
+}
+
+func ExampleParseByteSize() {
+	for _, in := range []string{
+		"42", "10MiB", "10 mib", "1.5 GB", "1.5gb", "512k", "2 KB",
+		"0.5 KiB", "1 byte", "16EiB", "18446744073709551615",
+	} {
+		n, err := to.ParseByteSize(in)
+		fmt.Println(n, err)
+	}
+	// Output:
+	// 42 <nil>
+	// 10485760 <nil>
+	// 10485760 <nil>
+	// 1500000000 <nil>
+	// 1500000000 <nil>
+	// 524288 <nil>
+	// 2000 <nil>
+	// 512 <nil>
+	// 1 <nil>
+	// 0 byte size overflows uint64: "16EiB"
+	// 18446744073709551615 <nil>
+}
+
+func ExampleParseByteSize_errors() {
+	for _, in := range []string{"", "MiB", "10 XB", "-1", "1.2.3k", "99999999999999999999"} {
+		_, err := to.ParseByteSize(in)
+		fmt.Println(err)
+	}
+	// Output:
+	// invalid byte size: ""
+	// invalid byte size: "MiB"
+	// invalid byte size unit: "XB"
+	// invalid byte size: "-1"
+	// invalid byte size: "1.2.3k": strconv.ParseFloat: parsing "1.2.3": invalid syntax
+	// invalid byte size: "99999999999999999999": strconv.ParseUint: parsing "99999999999999999999": value out of range
+}
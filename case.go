@@ -0,0 +1,157 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms contains the (uppercase) words that are always kept
+// entirely uppercase by CamelCase, PascalCase, and TitleCase (unless
+// first in CamelCase). They are those used by the Go linters. Others may
+// be passed to each as needed.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+	"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true,
+	"QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true,
+	"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true,
+	"TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+	"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// CaseWords splits the input into the words used by the case
+// converters. Anything that is not a letter or digit separates words
+// and is dropped. Words are also split on every change from lower case
+// or digits to upper case and before the last upper case letter of a
+// run that is followed by a lower case letter so that acronyms are kept
+// together (HTTPServer becomes HTTP and Server) unless the lower case
+// letter is a single s ending the word (URLs). Digits stay with the
+// letters before them (UTF8String becomes UTF8 and String). Case is
+// preserved.
+func CaseWords[T Text](in T) []string {
+	var words []string
+	var word []rune
+	runes := []rune(in)
+	split := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	for n, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			split()
+			continue
+		}
+		if n > 0 && len(word) > 0 {
+			prev := runes[n-1]
+			switch {
+			case (unicode.IsLower(prev) || unicode.IsDigit(prev)) &&
+				unicode.IsUpper(r):
+				split()
+			case unicode.IsUpper(prev) && unicode.IsUpper(r) &&
+				n+1 < len(runes) && unicode.IsLower(runes[n+1]) &&
+				!pluralAcronym(runes, n+1):
+				split()
+			}
+		}
+		word = append(word, r)
+	}
+	split()
+	return words
+}
+
+// pluralAcronym returns true if the rune at n is a single s ending a
+// word after an acronym (URLs, userIDs).
+func pluralAcronym(runes []rune, n int) bool {
+	return runes[n] == 's' && (n+1 == len(runes) || !unicode.IsLower(runes[n+1]))
+}
+
+// capitalized returns the word in all upper case if it is one of the
+// initialisms or extra (or a plural of one, which keeps a lower case s),
+// otherwise with the first rune in title case and the rest lower case.
+func capitalized(word string, extra []string) string {
+	up := strings.ToUpper(word)
+	if isInitialism(up, extra) {
+		return up
+	}
+	if single := strings.TrimSuffix(up, "S"); single != up && isInitialism(single, extra) {
+		return single + "s"
+	}
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToTitle(runes[0])
+	return string(runes)
+}
+
+// isInitialism returns true if the upper case word is one of the
+// initialisms or extra.
+func isInitialism(up string, extra []string) bool {
+	if initialisms[up] {
+		return true
+	}
+	for _, e := range extra {
+		if strings.ToUpper(e) == up {
+			return true
+		}
+	}
+	return false
+}
+
+// CamelCase returns the input as camelCase words (see CaseWords)
+// keeping common initialisms (and any extra) in upper case after the
+// first word (userID).
+func CamelCase[T Text](in T, extra ...string) string {
+	words := CaseWords(in)
+	for n, word := range words {
+		if n == 0 {
+			words[n] = strings.ToLower(word)
+			continue
+		}
+		words[n] = capitalized(word, extra)
+	}
+	return strings.Join(words, "")
+}
+
+// PascalCase returns the input as PascalCase words (see CaseWords)
+// keeping common initialisms (and any extra) in upper case (UserID).
+func PascalCase[T Text](in T, extra ...string) string {
+	words := CaseWords(in)
+	for n, word := range words {
+		words[n] = capitalized(word, extra)
+	}
+	return strings.Join(words, "")
+}
+
+// SnakeCase returns the input as lower case words (see CaseWords)
+// joined with underscores (user_id).
+func SnakeCase[T Text](in T) string {
+	return strings.ToLower(strings.Join(CaseWords(in), "_"))
+}
+
+// KebabCase returns the input as lower case words (see CaseWords)
+// joined with dashes (user-id).
+func KebabCase[T Text](in T) string {
+	return strings.ToLower(strings.Join(CaseWords(in), "-"))
+}
+
+// ConstCase returns the input as upper case words (see CaseWords)
+// joined with underscores (USER_ID).
+func ConstCase[T Text](in T) string {
+	return strings.ToUpper(strings.Join(CaseWords(in), "_"))
+}
+
+// TitleCase returns the input as capitalized words (see CaseWords)
+// joined with a single space keeping common initialisms (and any extra)
+// in upper case (User ID). No attempt is made to keep small words (of,
+// the) in lower case.
+func TitleCase[T Text](in T, extra ...string) string {
+	words := CaseWords(in)
+	for n, word := range words {
+		words[n] = capitalized(word, extra)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,90 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleCaseWords() {
+	fmt.Printf("%q\n", to.CaseWords("HTTPServerID"))
+	fmt.Printf("%q\n", to.CaseWords("some_thing-here now"))
+	fmt.Printf("%q\n", to.CaseWords("v2Api"))
+	fmt.Printf("%q\n", to.CaseWords([]rune("ÜberGroße straße")))
+	fmt.Printf("%q\n", to.CaseWords("URLs"))
+	fmt.Printf("%q\n", to.CaseWords("userIDsByURLsList"))
+	fmt.Println(to.SnakeCase("URLs"), to.KebabCase("userIDs"), to.PascalCase("user_ids"))
+	// Output:
+	// ["HTTP" "Server" "ID"]
+	// ["some" "thing" "here" "now"]
+	// ["v2" "Api"]
+	// ["Über" "Große" "straße"]
+	// ["URLs"]
+	// ["user" "IDs" "By" "URLs" "List"]
+	// urls user-ids UserIDs
+}
+
+func ExampleCamelCase() {
+	fmt.Println(to.CamelCase("user_id"))
+	fmt.Println(to.CamelCase("HTTPServer"))
+	fmt.Println(to.CamelCase("ID token"))
+	fmt.Println(to.CamelCase("parse-url-string"))
+	// Output:
+	// userID
+	// httpServer
+	// idToken
+	// parseURLString
+}
+
+func ExamplePascalCase() {
+	fmt.Println(to.PascalCase("user_id"))
+	fmt.Println(to.PascalCase("http server"))
+	fmt.Println(to.PascalCase("überGroß"))
+	fmt.Println(to.PascalCase("utf8_string"))
+	fmt.Println(to.PascalCase("grpc_client"))
+	fmt.Println(to.PascalCase("grpc_client", "GRPC"))
+	// Output:
+	// UserID
+	// HTTPServer
+	// ÜberGroß
+	// UTF8String
+	// GrpcClient
+	// GRPCClient
+}
+
+func ExampleSnakeCase() {
+	fmt.Println(to.SnakeCase("HTTPServerID"))
+	fmt.Println(to.SnakeCase("someThing here"))
+	fmt.Println(to.SnakeCase("base64Encode"))
+	// Output:
+	// http_server_id
+	// some_thing_here
+	// base64_encode
+}
+
+func ExampleKebabCase() {
+	fmt.Println(to.KebabCase("HTTPServerID"))
+	fmt.Println(to.KebabCase("SOME_CONST"))
+	// Output:
+	// http-server-id
+	// some-const
+}
+
+func ExampleConstCase() {
+	fmt.Println(to.ConstCase("maxRetryCount"))
+	fmt.Println(to.ConstCase("default-url"))
+	// Output:
+	// MAX_RETRY_COUNT
+	// DEFAULT_URL
+}
+
+func ExampleTitleCase() {
+	fmt.Println(to.TitleCase("the_user_id"))
+	fmt.Println(to.TitleCase("someHTMLThing"))
+	// Output:
+	// The User ID
+	// Some HTML Thing
+}
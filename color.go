@@ -0,0 +1,264 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGB is a 24-bit (true) color.
+type RGB struct{ R, G, B uint8 }
+
+// HSL is a color as hue (0-360), saturation (0-1), and lightness (0-1).
+type HSL struct{ H, S, L float64 }
+
+// ParseHex returns the RGB color from a hex string with or without the
+// leading # in either the long (#ff8700) or short (#f80) form without
+// regard to case.
+func ParseHex(in string) (RGB, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(in), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return RGB{}, fmt.Errorf("invalid hex color: %q", in)
+	}
+	n, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return RGB{}, fmt.Errorf("invalid hex color: %q", in)
+	}
+	return RGB{uint8(n >> 16), uint8(n >> 8), uint8(n)}, nil
+}
+
+// Hex returns the color as a lower case hex string (#ff8700).
+func (c RGB) Hex() string { return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B) }
+
+// String fulfills fmt.Stringer with Hex.
+func (c RGB) String() string { return c.Hex() }
+
+// HSL returns the color as hue, saturation, and lightness.
+func (c RGB) HSL() HSL {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	l := (max + min) / 2
+	if max == min {
+		return HSL{0, 0, l}
+	}
+	d := max - min
+	s := d / (1 - math.Abs(2*l-1))
+	var h float64
+	switch max {
+	case r:
+		h = math.Mod((g-b)/d, 6)
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	return HSL{h, s, l}
+}
+
+// RGB returns the color as red, green, and blue.
+func (c HSL) RGB() RGB {
+	h := math.Mod(c.H, 360)
+	if h < 0 {
+		h += 360
+	}
+	chroma := (1 - math.Abs(2*c.L-1)) * c.S
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := c.L - chroma/2
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = chroma, x, 0
+	case h < 120:
+		r, g, b = x, chroma, 0
+	case h < 180:
+		r, g, b = 0, chroma, x
+	case h < 240:
+		r, g, b = 0, x, chroma
+	case h < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+	to8 := func(v float64) uint8 { return uint8(math.Round((v + m) * 255)) }
+	return RGB{to8(r), to8(g), to8(b)}
+}
+
+// xterm16 contains the default xterm colors for the first 16 indexes.
+var xterm16 = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// XTerm returns the RGB color of the xterm 256 color index: 16 system
+// colors (ANSI-16 with xterm defaults), a 6x6x6 color cube, and 24
+// grays. Indexes outside of 0-255 are wrapped.
+func XTerm(n int) RGB {
+	n &= 0xff
+	switch {
+	case n < 16:
+		return xterm16[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) uint8 {
+			if v == 0 {
+				return 0
+			}
+			return uint8(55 + v*40)
+		}
+		return RGB{level(n / 36), level(n / 6 % 6), level(n % 6)}
+	}
+	g := uint8(8 + (n-232)*10)
+	return RGB{g, g, g}
+}
+
+// lab is a color in the CIELAB color space where the distance between
+// two colors approximates how different they look.
+type lab struct{ l, a, b float64 }
+
+func (c RGB) lab() lab {
+	lin := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.04045 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	r, g, b := lin(c.R), lin(c.G), lin(c.B)
+	x := (0.4124*r + 0.3576*g + 0.1805*b) / 0.95047
+	y := 0.2126*r + 0.7152*g + 0.0722*b
+	z := (0.0193*r + 0.1192*g + 0.9505*b) / 1.08883
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return lab{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+// Distance returns the perceptual distance (CIE76 delta E) between the
+// colors. A distance less than about 2.3 is barely noticeable.
+func (c RGB) Distance(o RGB) float64 {
+	a, b := c.lab(), o.lab()
+	return math.Sqrt((a.l-b.l)*(a.l-b.l) + (a.a-b.a)*(a.a-b.a) + (a.b-b.b)*(a.b-b.b))
+}
+
+// nearest returns the xterm index (from first to last) perceptually
+// closest to the color.
+func (c RGB) nearest(first, last int) int {
+	best, dist := first, math.MaxFloat64
+	for n := first; n <= last; n++ {
+		if d := c.Distance(XTerm(n)); d < dist {
+			best, dist = n, d
+		}
+	}
+	return best
+}
+
+// XTerm256 returns the xterm 256 color index perceptually closest to
+// the color. Only the color cube and grays (16-255) are considered
+// since the first 16 vary between terminals.
+func (c RGB) XTerm256() int { return c.nearest(16, 255) }
+
+// ANSI16 returns the ANSI-16 color index (0-15) perceptually closest to
+// the color (see XTerm).
+func (c RGB) ANSI16() int { return c.nearest(0, 15) }
+
+// Color depths for Downsampled.
+const (
+	Colors16  = 16
+	Colors256 = 256
+)
+
+// Downsampled returns the input with the colors of every SGR escape
+// sequence (see Escapes) rewritten to the perceptually nearest color
+// of the given depth (Colors16 or Colors256). 24-bit colors are reduced
+// to 256 or 16 and 256 colors to 16. Everything else is left as is.
+func Downsampled(in string, depth int) string {
+	escs := Escapes(in)
+	if len(escs) == 0 {
+		return in
+	}
+	var out strings.Builder
+	var last int
+	for _, e := range escs {
+		out.WriteString(in[last:e.Offset])
+		last = e.Offset + len(e.Seq)
+		if !strings.HasPrefix(e.Seq, "\033[") || !strings.HasSuffix(e.Seq, "m") {
+			out.WriteString(e.Seq)
+			continue
+		}
+		out.WriteString("\033[" + downsampledSGR(e.Seq[2:len(e.Seq)-1], depth) + "m")
+	}
+	out.WriteString(in[last:])
+	return out.String()
+}
+
+func downsampledSGR(params string, depth int) string {
+	fields := strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' })
+	var out []string
+	for n := 0; n < len(fields); n++ {
+		f := fields[n]
+		if (f != "38" && f != "48") || n+1 >= len(fields) {
+			out = append(out, f)
+			continue
+		}
+		var c RGB
+		var index = -1
+		switch {
+		case fields[n+1] == "5" && n+2 < len(fields):
+			index, _ = strconv.Atoi(fields[n+2])
+			c = XTerm(index)
+			n += 2
+		case fields[n+1] == "2" && n+4 < len(fields):
+			r, _ := strconv.Atoi(fields[n+2])
+			g, _ := strconv.Atoi(fields[n+3])
+			b, _ := strconv.Atoi(fields[n+4])
+			c = RGB{uint8(r), uint8(g), uint8(b)}
+			n += 4
+		default:
+			out = append(out, f)
+			continue
+		}
+		bg := f == "48"
+		switch {
+		case depth >= Colors256 && index >= 0:
+			out = append(out, f, "5", strconv.Itoa(index))
+		case depth >= Colors256:
+			out = append(out, f, "5", strconv.Itoa(c.XTerm256()))
+		default:
+			if index < 0 || index > 15 {
+				index = c.ANSI16()
+			}
+			out = append(out, strconv.Itoa(sgr16(index, bg)))
+		}
+	}
+	return strings.Join(out, ";")
+}
+
+// sgr16 returns the SGR parameter for the ANSI-16 color index.
+func sgr16(index int, bg bool) int {
+	base := 30
+	if index > 7 {
+		base, index = 90, index-8
+	}
+	if bg {
+		base += 10
+	}
+	return base + index
+}
@@ -0,0 +1,70 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleParseHex() {
+	for _, in := range []string{"#ff8700", "FF8700", "#f80", "#bork", ""} {
+		c, err := to.ParseHex(in)
+		fmt.Println(c, c.R, c.G, c.B, err)
+	}
+	// Output:
+	// #ff8700 255 135 0 <nil>
+	// #ff8700 255 135 0 <nil>
+	// #ff8800 255 136 0 <nil>
+	// #000000 0 0 0 invalid hex color: "#bork"
+	// #000000 0 0 0 invalid hex color: ""
+}
+
+func ExampleRGB_HSL() {
+	colors := []to.RGB{
+		{R: 255, G: 0, B: 0},
+		{R: 0, G: 128, B: 0},
+		{R: 255, G: 135, B: 0},
+		{R: 128, G: 128, B: 128},
+	}
+	for _, c := range colors {
+		h := c.HSL()
+		fmt.Printf("%v %.1f %.2f %.2f %v\n", c, h.H, h.S, h.L, h.RGB())
+	}
+	// Output:
+	// #ff0000 0.0 1.00 0.50 #ff0000
+	// #008000 120.0 1.00 0.25 #008000
+	// #ff8700 31.8 1.00 0.50 #ff8700
+	// #808080 0.0 0.00 0.50 #808080
+}
+
+func ExampleXTerm() {
+	fmt.Println(to.XTerm(1), to.XTerm(12), to.XTerm(208), to.XTerm(244))
+	// Output:
+	// #cd0000 #5c5cff #ff8700 #808080
+}
+
+func ExampleRGB_XTerm256() {
+	fmt.Println(to.RGB{R: 255, G: 135, B: 0}.XTerm256())
+	fmt.Println(to.RGB{R: 250, G: 130, B: 10}.XTerm256())
+	fmt.Println(to.RGB{R: 128, G: 128, B: 128}.XTerm256())
+	fmt.Println(to.RGB{R: 255, G: 135, B: 0}.ANSI16())
+	fmt.Println(to.RGB{R: 10, G: 10, B: 200}.ANSI16())
+	// Output:
+	// 208
+	// 208
+	// 244
+	// 1
+	// 4
+}
+
+func ExampleDownsampled() {
+	in := "\033[1;38;2;255;135;0morange\033[0m \033[48;5;196mred\033[0m \033[32mgreen\033[0m"
+	fmt.Printf("%q\n", to.Downsampled(in, to.Colors256))
+	fmt.Printf("%q\n", to.Downsampled(in, to.Colors16))
+	// Output:
+	// "\x1b[1;38;5;208morange\x1b[0m \x1b[48;5;196mred\x1b[0m \x1b[32mgreen\x1b[0m"
+	// "\x1b[1;31morange\x1b[0m \x1b[101mred\x1b[0m \x1b[32mgreen\x1b[0m"
+}
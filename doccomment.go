@@ -0,0 +1,351 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"regexp"
+	"strings"
+)
+
+// dcKind is the kind of a Go doc comment block.
+type dcKind int
+
+const (
+	dcPara dcKind = iota
+	dcHeading
+	dcCode
+	dcList
+	dcLinks
+)
+
+// dcItem is a single list item with an optional number (bullets have
+// none).
+type dcItem struct {
+	number string
+	text   []string
+}
+
+// dcBlock is a single block of a Go doc comment (see go/doc/comment).
+type dcBlock struct {
+	kind  dcKind
+	lines []string
+	items []dcItem
+	loose bool
+}
+
+var (
+	dcItemRe = regexp.MustCompile(`^[ \t]*(?:[-*+•]|([0-9]+)[.)])[ \t]+(.*)$`)
+	dcLinkRe = regexp.MustCompile(`^\[[^\]]+\]:[ \t]+\S+$`)
+)
+
+func dcIndented(line string) bool {
+	return line != "" && (line[0] == ' ' || line[0] == '\t')
+}
+
+// dcParse divides the lines into blocks following the go/doc/comment
+// rules except that list items need not be indented.
+func dcParse(lines []string) []dcBlock {
+	var blocks []dcBlock
+	for n := 0; n < len(lines); {
+		line := lines[n]
+		switch {
+
+		case isblank.MatchString(line):
+			n++
+
+		case dcItemRe.MatchString(line):
+			list := dcBlock{kind: dcList}
+			// a list ends when bullets change to numbers (or back)
+			numbered := dcItemRe.FindStringSubmatch(line)[1] != ""
+			same := func(l string) bool {
+				m := dcItemRe.FindStringSubmatch(l)
+				return m != nil && (m[1] != "") == numbered
+			}
+			for n < len(lines) {
+				l := lines[n]
+				if m := dcItemRe.FindStringSubmatch(l); m != nil {
+					if !same(l) {
+						break
+					}
+					list.items = append(list.items, dcItem{number: m[1], text: []string{m[2]}})
+					n++
+					continue
+				}
+				if isblank.MatchString(l) {
+					k := n
+					for k < len(lines) && isblank.MatchString(lines[k]) {
+						k++
+					}
+					if k < len(lines) && same(lines[k]) {
+						list.loose = true
+						n = k
+						continue
+					}
+					break
+				}
+				last := &list.items[len(list.items)-1]
+				last.text = append(last.text, strings.TrimSpace(l))
+				n++
+			}
+			blocks = append(blocks, list)
+
+		case dcIndented(line):
+			var code []string
+			for n < len(lines) {
+				l := lines[n]
+				if isblank.MatchString(l) {
+					k := n
+					for k < len(lines) && isblank.MatchString(lines[k]) {
+						k++
+					}
+					if k == len(lines) || !dcIndented(lines[k]) {
+						break
+					}
+					for ; n < k; n++ {
+						code = append(code, "")
+					}
+					continue
+				}
+				if !dcIndented(l) {
+					break
+				}
+				code = append(code, strings.TrimRight(l, " \t"))
+				n++
+			}
+			// remove the indentation common to every line
+			var indent string
+			var seen bool
+			for _, l := range code {
+				if l == "" {
+					continue
+				}
+				pre := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+				if !seen {
+					indent, seen = pre, true
+				}
+				for !strings.HasPrefix(pre, indent) {
+					indent = indent[:len(indent)-1]
+				}
+			}
+			for c, l := range code {
+				code[c] = strings.TrimPrefix(l, indent)
+			}
+			blocks = append(blocks, dcBlock{kind: dcCode, lines: code})
+
+		case strings.HasPrefix(line, "# ") &&
+			(n+1 == len(lines) || isblank.MatchString(lines[n+1])):
+			blocks = append(blocks, dcBlock{kind: dcHeading, lines: []string{line[2:]}})
+			n++
+
+		default:
+			para := dcBlock{kind: dcPara}
+			links := true
+			for n < len(lines) && !isblank.MatchString(lines[n]) &&
+				!dcIndented(lines[n]) && !dcItemRe.MatchString(lines[n]) {
+				links = links && dcLinkRe.MatchString(lines[n])
+				para.lines = append(para.lines, lines[n])
+				n++
+			}
+			if links {
+				para.kind = dcLinks
+			}
+			blocks = append(blocks, para)
+		}
+	}
+	return blocks
+}
+
+// Commented returns the text as a Go doc comment with every line
+// beginning with // and wrapped (see Wrapped) so that no line (other
+// than those with words that are too long) exceeds width. Blocks follow
+// the go/doc/comment rules (and therefore survive gofmt unchanged):
+//
+//   - paragraphs are separated by blank lines and rewrapped
+//   - a single line beginning with "# " is a heading
+//   - indented lines (that are not list items) are code and are kept as
+//     is (indented with a single tab)
+//   - lines beginning with -, *, +, or • (bullets) or a number followed by
+//     a period or parenthesis are list items (indented or not)
+//   - a paragraph of nothing but link definitions ([Text]: URL) is
+//     moved to the end
+//
+// A list ends where bullets change to numbers (or back). Since a doc
+// comment takes any indented lines after a list as part of it, a list
+// directly after another is not indented (as gofmt would have it).
+//
+// See Uncommented for the reverse.
+func Commented(in string, width int) string {
+	var out, links []string
+	var afterList bool
+	for _, b := range dcParse(Lines(in)) {
+		if b.kind == dcLinks {
+			links = append(links, b.lines...)
+			continue
+		}
+		if len(out) > 0 {
+			out = append(out, "")
+		}
+		switch b.kind {
+
+		case dcHeading:
+			out = append(out, " # "+Words(b.lines[0]))
+
+		case dcCode:
+			for _, line := range b.lines {
+				if line != "" {
+					line = "\t" + line
+				}
+				out = append(out, line)
+			}
+
+		case dcList:
+			for i, item := range b.items {
+				if i > 0 && b.loose {
+					out = append(out, "")
+				}
+				text := strings.Join(item.text, " ")
+				if afterList {
+					pre := "- "
+					if item.number != "" {
+						pre = item.number + ". "
+					}
+					wrapped, _ := Wrapped(pre+text, width-3)
+					for _, line := range Lines(wrapped) {
+						out = append(out, " "+line)
+					}
+					continue
+				}
+				pre := "   - "
+				if item.number != "" {
+					pre = "  " + item.number + ". "
+				}
+				if strings.TrimSpace(text) == "" {
+					out = append(out, strings.TrimRight(pre, " "))
+					continue
+				}
+				wrapped, _ := Wrapped(text, width-2-len(pre))
+				for l, line := range Lines(wrapped) {
+					if l == 0 {
+						out = append(out, pre+line)
+						continue
+					}
+					out = append(out, "     "+line)
+				}
+			}
+
+		default:
+			wrapped, _ := Wrapped(strings.Join(b.lines, " "), width-3)
+			for _, line := range Lines(wrapped) {
+				out = append(out, " "+line)
+			}
+		}
+		afterList = b.kind == dcList && !afterList
+	}
+	if len(links) > 0 {
+		if len(out) > 0 {
+			out = append(out, "")
+		}
+		for _, link := range links {
+			out = append(out, " "+link)
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return "//" + strings.Join(out, "\n//") + "\n"
+}
+
+// Uncommented returns the text of the first comment found in the input
+// (either a block of // lines or a single /* */ comment) with the
+// comment markers removed and every paragraph and list item unwrapped
+// to a single line. Code blocks are kept (indented with a single tab),
+// headings begin with "# ", and bullets become dashes. Blocks are
+// separated by a blank line. See Commented for the reverse.
+func Uncommented(in string) string {
+	var lines, block []string
+	var started, inblock bool
+	for _, l := range Lines(in) {
+		t := strings.TrimLeft(l, " \t")
+		if inblock {
+			before, _, found := strings.Cut(l, "*/")
+			block = append(block, strings.TrimRight(before, " \t"))
+			if found {
+				break
+			}
+			continue
+		}
+		if strings.HasPrefix(t, "//") {
+			started = true
+			lines = append(lines, strings.TrimPrefix(t[2:], " "))
+			continue
+		}
+		if started {
+			break
+		}
+		if strings.HasPrefix(t, "/*") {
+			if before, _, found := strings.Cut(t[2:], "*/"); found {
+				lines = append(lines, strings.TrimSpace(before))
+				break
+			}
+			inblock = true
+			lines = append(lines, strings.TrimSpace(t[2:]))
+		}
+	}
+
+	// lines after the first within /* */ are usually indented to line
+	// up with it
+	if len(block) > 0 {
+		indent := -1
+		for _, l := range block {
+			if i := len(l) - len(strings.TrimLeft(l, " \t")); l != "" && (indent < 0 || i < indent) {
+				indent = i
+			}
+		}
+		for _, l := range block {
+			if len(l) >= indent && indent > 0 {
+				l = l[indent:]
+			}
+			lines = append(lines, l)
+		}
+	}
+
+	var out []string
+	for _, b := range dcParse(lines) {
+		switch b.kind {
+		case dcHeading:
+			out = append(out, "# "+Words(b.lines[0]))
+		case dcCode:
+			code := make([]string, len(b.lines))
+			for n, line := range b.lines {
+				if line != "" {
+					line = "\t" + line
+				}
+				code[n] = line
+			}
+			out = append(out, strings.Join(code, "\n"))
+		case dcList:
+			items := make([]string, len(b.items))
+			for n, item := range b.items {
+				pre := "- "
+				if item.number != "" {
+					pre = item.number + ". "
+				}
+				items[n] = pre + Words(strings.Join(item.text, " "))
+			}
+			sep := "\n"
+			if b.loose {
+				sep = "\n\n"
+			}
+			out = append(out, strings.Join(items, sep))
+		case dcLinks:
+			out = append(out, strings.Join(b.lines, "\n"))
+		default:
+			out = append(out, Words(strings.Join(b.lines, " ")))
+		}
+	}
+	if len(out) == 0 {
+		return ""
+	}
+	return strings.Join(out, "\n\n") + "\n"
+}
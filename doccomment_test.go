@@ -0,0 +1,127 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"go/format"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleCommented() {
+	in := `Commented returns the text as a Go doc comment wrapped
+at the given width. See [Wrapped] for details.
+
+# Rules
+
+- paragraphs are
+  rewrapped
+* bullets become dashes
+10. numbers are kept
+
+[Wrapped]: https://pkg.go.dev/github.com/rwxrob/to#Wrapped
+
+    out := to.Commented(in, 40)
+        fmt.Print(out)
+`
+	fmt.Print(to.Commented(in, 40))
+	// Output:
+	// // Commented returns the text as a Go
+	// // doc comment wrapped at the given
+	// // width. See [Wrapped] for details.
+	// //
+	// // # Rules
+	// //
+	// //   - paragraphs are rewrapped
+	// //   - bullets become dashes
+	// //
+	// // 10. numbers are kept
+	// //
+	// //	out := to.Commented(in, 40)
+	// //	    fmt.Print(out)
+	// //
+	// // [Wrapped]: https://pkg.go.dev/github.com/rwxrob/to#Wrapped
+}
+
+func ExampleUncommented() {
+	in := `
+package foo
+
+	// Foo does something with
+	// a very long description.
+	//
+	//   - first item that
+	//     wraps
+	//   - second
+	//
+	//	code := Foo()
+	func Foo() {}
+
+	// another comment is ignored
+`
+	fmt.Print(to.Uncommented(in))
+	fmt.Print(to.Uncommented("/* block\n   comment */"))
+	// Output:
+	// Foo does something with a very long description.
+	//
+	// - first item that wraps
+	// - second
+	//
+	// 	code := Foo()
+	// block comment
+}
+
+func ExampleCommented_roundTrip() {
+	text := to.Uncommented(to.Commented("1. one\n\n2. two\n", 20))
+	fmt.Print(text)
+	fmt.Print(to.Commented(text, 20))
+	// Output:
+	// 1. one
+	//
+	// 2. two
+	// //  1. one
+	// //
+	// //  2. two
+}
+
+func ExampleCommented_empty() {
+	fmt.Print(to.Commented("- ", 20))
+	fmt.Print(to.Commented("Steps:\n\n1. \n2. do it", 72))
+	fmt.Printf("%q\n", to.Commented("    a\n\tb\n    c", 72))
+	// Output:
+	// //   -
+	// // Steps:
+	// //
+	// //  1.
+	// //  2. do it
+	// "//\t    a\n//\t\tb\n//\t    c\n"
+}
+
+func ExampleCommented_gofmt() {
+	for _, in := range []string{
+		"- one\n- two\n\n1. first\n2. second",
+		"Steps:\n\n1. first\n2. second\n- one\n\n- two\n\n3. third",
+		"Intro:\n\n* a\n\n* b\n\n# Head\n\n    code\n\nDone.",
+	} {
+		src := "package p\n\n" + to.Commented(in, 40) + "func F() {}\n"
+		out, err := format.Source([]byte(src))
+		fmt.Println(err, string(out) == src)
+	}
+	fmt.Print(to.Commented("Steps:\n\n1. first\n2. second\n- one\n\n- two\n\n3. third", 40))
+	// Output:
+	// <nil> true
+	// <nil> true
+	// <nil> true
+	// // Steps:
+	// //
+	// //  1. first
+	// //  2. second
+	// //
+	// // - one
+	// //
+	// // - two
+	// //
+	// //  3. third
+}
@@ -0,0 +1,275 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"encoding/ascii85"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// Encoder contains the configuration used to encode bytes as text and
+// decode them again. URL selects the URL and file name safe alphabet
+// for Base64 (- and _ instead of + and /). NoPad omits the trailing =
+// padding of Base64 and Base32 (decoding always accepts either). If
+// Wrap is greater than 0 the encoded text is broken into lines of that
+// many characters (64 for PEM, for example). All white space is ignored
+// when decoding.
+type Encoder struct {
+	URL   bool
+	NoPad bool
+	Wrap  int
+}
+
+// DecodeError is returned when the encoded text is invalid. Offset is
+// that of the first invalid byte (or the end when the text is cut
+// short) within the original input, white space included.
+type DecodeError struct {
+	Encoding string
+	Offset   int
+	Msg      string
+}
+
+func (e DecodeError) Error() string {
+	return fmt.Sprintf("invalid %v at offset %v: %v", e.Encoding, e.Offset, e.Msg)
+}
+
+// Base64 is shorthand for Encoder{}.Base64.
+func Base64(in any) string { return Encoder{}.Base64(in) }
+
+// Base32 is shorthand for Encoder{}.Base32.
+func Base32(in any) string { return Encoder{}.Base32(in) }
+
+// Base58 is shorthand for Encoder{}.Base58.
+func Base58(in any) string { return Encoder{}.Base58(in) }
+
+// Ascii85 is shorthand for Encoder{}.Ascii85.
+func Ascii85(in any) string { return Encoder{}.Ascii85(in) }
+
+// Hex is shorthand for Encoder{}.Hex.
+func Hex(in any) string { return Encoder{}.Hex(in) }
+
+// UnBase64 is shorthand for Encoder{}.UnBase64 and accepts either
+// alphabet.
+func UnBase64(in any) ([]byte, error) {
+	s := String(in)
+	return Encoder{URL: strings.ContainsAny(s, "-_")}.UnBase64(s)
+}
+
+// UnBase32 is shorthand for Encoder{}.UnBase32.
+func UnBase32(in any) ([]byte, error) { return Encoder{}.UnBase32(in) }
+
+// UnBase58 is shorthand for Encoder{}.UnBase58.
+func UnBase58(in any) ([]byte, error) { return Encoder{}.UnBase58(in) }
+
+// UnAscii85 is shorthand for Encoder{}.UnAscii85.
+func UnAscii85(in any) ([]byte, error) { return Encoder{}.UnAscii85(in) }
+
+// UnHex is shorthand for Encoder{}.UnHex.
+func UnHex(in any) ([]byte, error) { return Encoder{}.UnHex(in) }
+
+// wrapped breaks the encoded text into lines of Wrap characters.
+func (e Encoder) wrapped(s string) string {
+	if e.Wrap < 1 || len(s) <= e.Wrap {
+		return s
+	}
+	var out strings.Builder
+	for len(s) > e.Wrap {
+		out.WriteString(s[:e.Wrap] + "\n")
+		s = s[e.Wrap:]
+	}
+	out.WriteString(s)
+	return out.String()
+}
+
+// compact returns the input without any white space and the offset of
+// every remaining byte within the original (with the length at the
+// end) so that errors can report where they actually are.
+func compact(in string) (string, []int) {
+	var out strings.Builder
+	offsets := make([]int, 0, len(in)+1)
+	for n := 0; n < len(in); n++ {
+		if c := in[n]; c < 0x80 && unicode.IsSpace(rune(c)) {
+			continue
+		}
+		out.WriteByte(in[n])
+		offsets = append(offsets, n)
+	}
+	return out.String(), append(offsets, len(in))
+}
+
+// the Base64 alphabets (standard and URL)
+const (
+	base64Std = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	base64URL = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+)
+
+func (e Encoder) base64() *base64.Encoding {
+	enc := base64.StdEncoding
+	if e.URL {
+		enc = base64.URLEncoding
+	}
+	if e.NoPad {
+		enc = enc.WithPadding(base64.NoPadding)
+	}
+	return enc
+}
+
+// Base64 returns the input (anything Bytes accepts) encoded as Base64
+// (RFC 4648).
+func (e Encoder) Base64(in any) string {
+	return e.wrapped(e.base64().EncodeToString(Bytes(in)))
+}
+
+// UnBase64 returns the decoded Base64 (anything String accepts) or
+// a DecodeError.
+func (e Encoder) UnBase64(in any) ([]byte, error) {
+	s, offsets := compact(String(in))
+	trimmed := strings.TrimRight(s, "=")
+	b, err := e.base64().WithPadding(base64.NoPadding).DecodeString(trimmed)
+	var corrupt base64.CorruptInputError
+	if errors.As(err, &corrupt) {
+		alphabet := base64Std
+		if e.URL {
+			alphabet = base64URL
+		}
+		msg := "illegal character"
+		// a single character left over can only be cut short
+		n := int(corrupt)
+		if len(trimmed)%4 == 1 && n >= len(trimmed)-1 &&
+			(n == len(trimmed) || strings.IndexByte(alphabet, trimmed[n]) >= 0) {
+			msg = "unexpected end"
+		}
+		return b, DecodeError{"base64", offsets[corrupt], msg}
+	}
+	return b, err
+}
+
+func (e Encoder) base32() *base32.Encoding {
+	enc := base32.StdEncoding
+	if e.NoPad {
+		enc = enc.WithPadding(base32.NoPadding)
+	}
+	return enc
+}
+
+// Base32 returns the input (anything Bytes accepts) encoded as Base32
+// (RFC 4648).
+func (e Encoder) Base32(in any) string {
+	return e.wrapped(e.base32().EncodeToString(Bytes(in)))
+}
+
+// UnBase32 returns the decoded Base32 (anything String accepts) or
+// a DecodeError.
+func (e Encoder) UnBase32(in any) ([]byte, error) {
+	s, offsets := compact(String(in))
+	trimmed := strings.TrimRight(s, "=")
+	b, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(trimmed)
+	var corrupt base32.CorruptInputError
+	if errors.As(err, &corrupt) {
+		return b, DecodeError{"base32", offsets[corrupt], "illegal character"}
+	}
+	return b, err
+}
+
+// base58 is the Bitcoin alphabet (no 0, O, I, or l).
+const base58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base58 returns the input (anything Bytes accepts) encoded as Base58
+// using the Bitcoin alphabet with every leading zero byte as a 1.
+func (e Encoder) Base58(in any) string {
+	buf := Bytes(in)
+	var zeros int
+	for zeros < len(buf) && buf[zeros] == 0 {
+		zeros++
+	}
+	n := new(big.Int).SetBytes(buf)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var digits []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		digits = append(digits, base58[mod.Int64()])
+	}
+	for ; zeros > 0; zeros-- {
+		digits = append(digits, '1')
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return e.wrapped(string(digits))
+}
+
+// UnBase58 returns the decoded Base58 (anything String accepts) or
+// a DecodeError.
+func (e Encoder) UnBase58(in any) ([]byte, error) {
+	s, offsets := compact(String(in))
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	var zeros int
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58, s[i])
+		if d < 0 {
+			return nil, DecodeError{"base58", offsets[i], "illegal character"}
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// Ascii85 returns the input (anything Bytes accepts) encoded as Ascii85
+// (as used by btoa and PostScript, without the <~ ~> delimiters).
+func (e Encoder) Ascii85(in any) string {
+	buf := Bytes(in)
+	out := make([]byte, ascii85.MaxEncodedLen(len(buf)))
+	return e.wrapped(string(out[:ascii85.Encode(out, buf)]))
+}
+
+// UnAscii85 returns the decoded Ascii85 (anything String accepts, with
+// or without the <~ ~> delimiters) or a DecodeError.
+func (e Encoder) UnAscii85(in any) ([]byte, error) {
+	s, offsets := compact(String(in))
+	if strings.HasPrefix(s, "<~") {
+		s, offsets = s[2:], offsets[2:]
+	}
+	s = strings.TrimSuffix(s, "~>")
+	out := make([]byte, 4*len(s))
+	n, _, err := ascii85.Decode(out, []byte(s), true)
+	var corrupt ascii85.CorruptInputError
+	if errors.As(err, &corrupt) {
+		return out[:n], DecodeError{"ascii85", offsets[corrupt], "illegal character"}
+	}
+	return out[:n], err
+}
+
+// Hex returns the input (anything Bytes accepts) encoded as lower case
+// hexadecimal.
+func (e Encoder) Hex(in any) string {
+	return e.wrapped(hex.EncodeToString(Bytes(in)))
+}
+
+// UnHex returns the decoded hexadecimal (anything String accepts, upper
+// or lower case) or a DecodeError.
+func (e Encoder) UnHex(in any) ([]byte, error) {
+	s, offsets := compact(String(in))
+	for i := 0; i < len(s); i++ {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", rune(s[i])) {
+			return nil, DecodeError{"hex", offsets[i], "illegal character"}
+		}
+	}
+	if len(s)%2 != 0 {
+		return nil, DecodeError{"hex", offsets[len(s)], "unexpected end"}
+	}
+	return hex.DecodeString(s)
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleBase64() {
+	fmt.Println(to.Base64("hello?>"))
+	fmt.Println(to.Encoder{URL: true, NoPad: true}.Base64([]byte("hello?>")))
+	fmt.Println(to.Encoder{Wrap: 8}.Base64(strings.NewReader("hello world")))
+	fmt.Println(to.UnBase64("aGVsbG8/Pg=="))
+	fmt.Println(to.UnBase64("aGVsbG8_Pg"))
+	fmt.Println(to.UnBase64("aGVs\nbG8g\nd29y\nbGQ="))
+	_, err := to.UnBase64("aGVs\nbG!v")
+	fmt.Println(err)
+	_, err = to.UnBase64("aGVsb")
+	fmt.Println(err)
+	_, err = to.UnBase64("aGV!")
+	fmt.Println(err)
+	_, err = to.UnBase64("aGVs!")
+	fmt.Println(err)
+	// Output:
+	// aGVsbG8/Pg==
+	// aGVsbG8_Pg
+	// aGVsbG8g
+	// d29ybGQ=
+	// [104 101 108 108 111 63 62] <nil>
+	// [104 101 108 108 111 63 62] <nil>
+	// [104 101 108 108 111 32 119 111 114 108 100] <nil>
+	// invalid base64 at offset 7: illegal character
+	// invalid base64 at offset 4: unexpected end
+	// invalid base64 at offset 3: illegal character
+	// invalid base64 at offset 4: illegal character
+}
+
+func ExampleBase32() {
+	fmt.Println(to.Base32("hello"), to.Base32("hi"))
+	fmt.Println(to.Encoder{NoPad: true}.Base32("hi"))
+	b, err := to.UnBase32("NBUQ")
+	fmt.Printf("%q %v\n", b, err)
+	_, err = to.UnBase32("NB1Q====")
+	fmt.Println(err)
+	_, err = to.UnBase32("NBU!")
+	fmt.Println(err)
+	// Output:
+	// NBSWY3DP NBUQ====
+	// NBUQ
+	// "hi" <nil>
+	// invalid base32 at offset 2: illegal character
+	// invalid base32 at offset 3: illegal character
+}
+
+func ExampleBase58() {
+	fmt.Println(to.Base58("hello world"))
+	fmt.Println(to.Base58([]byte{0, 0, 1}))
+	b, err := to.UnBase58("StV1DL6CwTryKyV")
+	fmt.Printf("%q %v\n", b, err)
+	fmt.Println(to.UnBase58("112"))
+	_, err = to.UnBase58("StV1 DL0C")
+	fmt.Println(err)
+	// Output:
+	// StV1DL6CwTryKyV
+	// 112
+	// "hello world" <nil>
+	// [0 0 1] <nil>
+	// invalid base58 at offset 7: illegal character
+}
+
+func ExampleAscii85() {
+	fmt.Println(to.Ascii85("hello world"))
+	b, err := to.UnAscii85("<~BOu!rD]j7BEbo7~>")
+	fmt.Printf("%q %v\n", b, err)
+	_, err = to.UnAscii85("BOu!r\nD]j7{BEbo7")
+	fmt.Println(err)
+	// Output:
+	// BOu!rD]j7BEbo7
+	// "hello world" <nil>
+	// invalid ascii85 at offset 10: illegal character
+}
+
+func ExampleHex() {
+	fmt.Println(to.Hex("hi there"))
+	fmt.Println(to.Encoder{Wrap: 4}.Hex([]rune("hi there")))
+	b, err := to.UnHex("6869 2074\n6865 7265")
+	fmt.Printf("%q %v\n", b, err)
+	_, err = to.UnHex("68 6x")
+	fmt.Println(err)
+	_, err = to.UnHex("686 ")
+	fmt.Println(err)
+	// Output:
+	// 6869207468657265
+	// 6869
+	// 2074
+	// 6865
+	// 7265
+	// "hi there" <nil>
+	// invalid hex at offset 4: illegal character
+	// invalid hex at offset 4: unexpected end
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import "strings"
+
+// Escape is a single terminal escape sequence found by Escapes. Offset
+// is the byte offset of the sequence within the original input and
+// Plain is the byte offset at which it would be within the Unescaped
+// version of the input.
+type Escape struct {
+	Seq    string
+	Offset int
+	Plain  int
+}
+
+// escapeEnd returns the index just after the end of the escape sequence
+// beginning with the ESC at s[i]. The following (from ECMA-48) are
+// recognized:
+//
+//   - CSI (including SGR color): ESC [ params intermediates final
+//   - OSC (titles, hyperlinks): ESC ] ... terminated by BEL or ST
+//   - DCS, SOS, PM, APC: ESC P, X, ^, or _ ... terminated by ST
+//   - everything else: ESC intermediates final (ESC ( B, ESC 7)
+//
+// Unterminated sequences end at the end of the string. An ESC that
+// does not begin a valid sequence is a sequence by itself.
+func escapeEnd(s string, i int) int {
+	n := i + 1
+	if n >= len(s) {
+		return n
+	}
+	switch s[n] {
+
+	case '[':
+		n++
+		for n < len(s) && s[n] >= 0x20 && s[n] <= 0x3F {
+			n++
+		}
+		if n < len(s) && s[n] >= 0x40 && s[n] <= 0x7E {
+			n++
+		}
+		return n
+
+	case ']', 'P', 'X', '^', '_':
+		n++
+		for n < len(s) {
+			switch {
+			case s[n] == '\a' && s[i+1] == ']':
+				return n + 1
+			case s[n] == '\033' && n+1 < len(s) && s[n+1] == '\\':
+				return n + 2
+			}
+			n++
+		}
+		return n
+
+	}
+	for n < len(s) && s[n] >= 0x20 && s[n] <= 0x2F {
+		n++
+	}
+	if n < len(s) && s[n] >= 0x30 && s[n] <= 0x7E {
+		return n + 1
+	}
+	return i + 1
+}
+
+// Escapes returns every terminal escape sequence found in the input
+// (see Unescaped) in order. See Reescaped to put them back.
+func Escapes[T string | []byte | []rune](in T) []Escape {
+	s := string(in)
+	var escs []Escape
+	var removed int
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\033' {
+			continue
+		}
+		end := escapeEnd(s, i)
+		escs = append(escs, Escape{Seq: s[i:end], Offset: i, Plain: i - removed})
+		removed += end - i
+		i = end - 1
+	}
+	return escs
+}
+
+// Unescaped returns the input with all terminal escape sequences
+// removed including SGR (color), all other CSI sequences (cursor
+// movement), OSC (titles, hyperlinks), DCS, SOS, PM, APC, and two and
+// three byte sequences (ESC 7, ESC ( B). Unlike Visible, none of the
+// bytes of the sequence remain. See Escapes.
+func Unescaped[T string | []byte | []rune](in T) string {
+	s := string(in)
+	if strings.IndexByte(s, '\033') < 0 {
+		return s
+	}
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\033' {
+			out.WriteByte(s[i])
+			continue
+		}
+		i = escapeEnd(s, i) - 1
+	}
+	return out.String()
+}
+
+// Reescaped returns the plain text with the escapes inserted at their
+// Plain offsets. Escapes beyond the end of the text are added to the
+// end. This is useful for changing the text (without changing its
+// length) after it has been Unescaped and then restoring the escapes.
+func Reescaped(plain string, escs []Escape) string {
+	var out strings.Builder
+	var last int
+	for _, e := range escs {
+		at := e.Plain
+		if at > len(plain) {
+			at = len(plain)
+		}
+		if at > last {
+			out.WriteString(plain[last:at])
+			last = at
+		}
+		out.WriteString(e.Seq)
+	}
+	out.WriteString(plain[last:])
+	return out.String()
+}
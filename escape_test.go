@@ -0,0 +1,53 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleUnescaped() {
+	fmt.Printf("%q\n", to.Unescaped("\033[31mred\033[0m and \033[1;38;2;0;0;255mblue\033[m"))
+	fmt.Printf("%q\n", to.Unescaped("\033[2J\033[10;5Hcleared"))
+	fmt.Printf("%q\n", to.Unescaped("\033]0;title\a\033]8;;https://rwx.gg\033\\link\033]8;;\033\\"))
+	fmt.Printf("%q\n", to.Unescaped([]byte("\033Pdcs stuff\033\\\033(Bplain\0337")))
+	fmt.Printf("%q\n", to.Unescaped([]rune("💚\033[32mgreen\033[0m💚")))
+	fmt.Printf("%q\n", to.Unescaped("dangling\033[31"))
+	// Output:
+	// "red and blue"
+	// "cleared"
+	// "link"
+	// "plain"
+	// "💚green💚"
+	// "dangling"
+}
+
+func ExampleUnescaped_visible() {
+	in := "\033[31mred\033[0m"
+	fmt.Printf("%q\n", to.Visible(in))
+	fmt.Printf("%q\n", to.Unescaped(in))
+	// Output:
+	// "[31mred[0m"
+	// "red"
+}
+
+func ExampleEscapes() {
+	for _, e := range to.Escapes("a \033[31mred\033[0m word") {
+		fmt.Printf("%q %v %v\n", e.Seq, e.Offset, e.Plain)
+	}
+	// Output:
+	// "\x1b[31m" 2 2
+	// "\x1b[0m" 10 5
+}
+
+func ExampleReescaped() {
+	in := "a \033[31mred\033[0m word"
+	plain := strings.ToUpper(to.Unescaped(in))
+	fmt.Printf("%q\n", to.Reescaped(plain, to.Escapes(in)))
+	// Output:
+	// "A \x1b[31mRED\x1b[0m WORD"
+}
@@ -0,0 +1,261 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flattener contains the configuration used to Flatten nested maps
+// (and slices) into a single map with keys containing the full path to
+// each value (a.b.c) and to Unflatten them back. Sep (default ".")
+// separates the keys. If Brackets is true, slice indexes are written
+// as a[0].b instead of a.0.b. Any Sep, backslash, or (with Brackets)
+// opening square bracket within a key is escaped with a backslash as
+// are (without Brackets) keys containing only digits so that they are
+// not mistaken for indexes.
+type Flattener struct {
+	Sep      string
+	Brackets bool
+}
+
+// Flattened is shorthand for Flattener{}.Flatten.
+func Flattened(in map[string]any) map[string]any { return Flattener{}.Flatten(in) }
+
+// Unflattened is shorthand for Flattener{}.Unflatten.
+func Unflattened(in map[string]any) (map[string]any, error) {
+	return Flattener{}.Unflatten(in)
+}
+
+func (f Flattener) sep() string {
+	if f.Sep == "" {
+		return "."
+	}
+	return f.Sep
+}
+
+func (f Flattener) escape(key string) string {
+	key = strings.ReplaceAll(key, `\`, `\\`)
+	key = strings.ReplaceAll(key, f.sep(), `\`+f.sep())
+	if f.Brackets {
+		key = strings.ReplaceAll(key, `[`, `\[`)
+	} else if key != "" && strings.Trim(key, "0123456789") == "" {
+		key = `\` + key
+	}
+	return key
+}
+
+// Flatten returns a new map with a single entry for every leaf value of
+// the nested map[string]any and []any values. Empty maps and slices
+// are kept as leaves (new ones, never those of the input) so that
+// nothing is lost. The result is suitable
+// for merging with MergedMaps (for overlays from environment variables
+// or command line flags, for example) before being passed to
+// Unflatten.
+func (f Flattener) Flatten(in map[string]any) map[string]any {
+	out := map[string]any{}
+	f.flatten(out, "", in)
+	return out
+}
+
+func (f Flattener) flatten(out map[string]any, prefix string, in any) {
+	switch v := in.(type) {
+	case map[string]any:
+		if len(v) == 0 && prefix != "" {
+			out[prefix] = map[string]any{}
+			return
+		}
+		for k, val := range v {
+			key := f.escape(k)
+			if prefix != "" {
+				key = prefix + f.sep() + key
+			}
+			f.flatten(out, key, val)
+		}
+	case []any:
+		if len(v) == 0 {
+			out[prefix] = []any{}
+			return
+		}
+		for n, val := range v {
+			var key string
+			switch {
+			case f.Brackets:
+				key = prefix + "[" + strconv.Itoa(n) + "]"
+			case prefix == "":
+				key = strconv.Itoa(n)
+			default:
+				key = prefix + f.sep() + strconv.Itoa(n)
+			}
+			f.flatten(out, key, val)
+		}
+	default:
+		out[prefix] = in
+	}
+}
+
+// flatKey is a single part of a flattened key which is either a map key
+// or a slice index.
+type flatKey struct {
+	name  string
+	index int
+	isidx bool
+}
+
+// split splits the flattened key into its parts honoring escapes.
+// Without Brackets any part containing only digits is an index.
+func (f Flattener) split(key string) ([]flatKey, error) {
+	var parts []flatKey
+	var cur strings.Builder
+	var escaped bool
+	sep := f.sep()
+	push := func() {
+		name := cur.String()
+		cur.Reset()
+		if !f.Brackets && !escaped && name != "" && strings.Trim(name, "0123456789") == "" {
+			n, _ := strconv.Atoi(name)
+			parts = append(parts, flatKey{index: n, isidx: true})
+			return
+		}
+		parts = append(parts, flatKey{name: name})
+		escaped = false
+	}
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '\\':
+			if i+1 >= len(key) {
+				return nil, fmt.Errorf("trailing backslash in key %q", key)
+			}
+			i++
+			if strings.HasPrefix(key[i:], sep) {
+				cur.WriteString(sep)
+				i += len(sep) - 1
+			} else {
+				cur.WriteByte(key[i])
+			}
+			escaped = true
+		case strings.HasPrefix(key[i:], sep):
+			if cur.Len() > 0 || len(parts) == 0 || !parts[len(parts)-1].isidx || !f.Brackets {
+				push()
+			}
+			i += len(sep) - 1
+		case f.Brackets && key[i] == '[':
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated index in key %q", key)
+			}
+			n, err := strconv.Atoi(key[i+1 : i+end])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid index %q in key %q", key[i+1:i+end], key)
+			}
+			if cur.Len() > 0 || escaped || len(parts) == 0 {
+				push()
+			}
+			parts = append(parts, flatKey{index: n, isidx: true})
+			i += end
+		default:
+			cur.WriteByte(key[i])
+		}
+	}
+	if cur.Len() > 0 || escaped || len(parts) == 0 || !parts[len(parts)-1].isidx {
+		push()
+	}
+	return parts, nil
+}
+
+// Unflatten returns a new nested map from one created by Flatten (or
+// merged with others). Parts of keys that are indexes create []any
+// (with nil for any missing indexes). Returns an error if the keys
+// conflict (a.b as a value and a.b.c as a map, for example) or cannot
+// be parsed.
+func (f Flattener) Unflatten(in map[string]any) (map[string]any, error) {
+	keys := make([]string, 0, len(in))
+	for k := range in {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var root any = map[string]any{}
+	for _, key := range keys {
+		parts, err := f.split(key)
+		if err != nil {
+			return nil, err
+		}
+		if root, err = f.set(root, parts, in[key], key); err != nil {
+			return nil, err
+		}
+	}
+	m, _ := root.(map[string]any)
+	return m, nil
+}
+
+// set places the value at the path within the node (creating maps and
+// slices as needed) and returns the node which may have been replaced
+// (when a slice grows). Maps and slices are copied (see copied) so that
+// later keys never change those of the caller.
+func (f Flattener) set(node any, parts []flatKey, val any, key string) (any, error) {
+	if len(parts) == 0 {
+		if node != nil {
+			return nil, fmt.Errorf("conflicting key %q", key)
+		}
+		return copied(val), nil
+	}
+	p := parts[0]
+
+	if p.isidx {
+		if node == nil {
+			node = []any{}
+		}
+		list, is := node.([]any)
+		if !is {
+			return nil, fmt.Errorf("conflicting key %q (index into non-list)", key)
+		}
+		for len(list) <= p.index {
+			list = append(list, nil)
+		}
+		child, err := f.set(list[p.index], parts[1:], val, key)
+		if err != nil {
+			return nil, err
+		}
+		list[p.index] = child
+		return list, nil
+	}
+
+	if node == nil {
+		node = map[string]any{}
+	}
+	m, is := node.(map[string]any)
+	if !is {
+		return nil, fmt.Errorf("conflicting key %q (key into non-map)", key)
+	}
+	child, err := f.set(m[p.name], parts[1:], val, key)
+	if err != nil {
+		return nil, err
+	}
+	m[p.name] = child
+	return m, nil
+}
+
+// copied returns a deep copy of any map[string]any or []any (and those
+// within them) or the value as is.
+func copied(val any) any {
+	switch v := val.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[k] = copied(e)
+		}
+		return m
+	case []any:
+		list := make([]any, len(v))
+		for n, e := range v {
+			list[n] = copied(e)
+		}
+		return list
+	}
+	return val
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+var nested = map[string]any{
+	"server": map[string]any{
+		"host":  "localhost",
+		"ports": []any{80, map[string]any{"tls": 443}},
+	},
+	"debug":       true,
+	"with.dot":    "escaped",
+	"2022":        "digits",
+	"empty":       map[string]any{},
+	"empty_slice": []any{},
+}
+
+func ExampleFlattened() {
+	fmt.Println(to.Flattened(nested))
+	// Output:
+	// map[\2022:digits debug:true empty:map[] empty_slice:[] server.host:localhost server.ports.0:80 server.ports.1.tls:443 with\.dot:escaped]
+}
+
+func ExampleFlattener_Flatten() {
+	f := to.Flattener{Sep: "__", Brackets: true}
+	fmt.Println(f.Flatten(nested))
+	// Output:
+	// map[2022:digits debug:true empty:map[] empty_slice:[] server__host:localhost server__ports[0]:80 server__ports[1]__tls:443 with.dot:escaped]
+}
+
+func ExampleUnflattened() {
+	m, err := to.Unflattened(to.Flattened(nested))
+	fmt.Println(err)
+	fmt.Println(m)
+	// Output:
+	// <nil>
+	// map[2022:digits debug:true empty:map[] empty_slice:[] server:map[host:localhost ports:[80 map[tls:443]]] with.dot:escaped]
+}
+
+func ExampleFlattener_Unflatten() {
+	f := to.Flattener{Sep: "__", Brackets: true}
+	m, err := f.Unflatten(f.Flatten(nested))
+	fmt.Println(err)
+	fmt.Println(m)
+	// Output:
+	// <nil>
+	// map[2022:digits debug:true empty:map[] empty_slice:[] server:map[host:localhost ports:[80 map[tls:443]]] with.dot:escaped]
+}
+
+func ExampleUnflattened_overlay() {
+	env := map[string]any{"server.host": "example.com", "server.ports.2": 8080}
+	m, err := to.Unflattened(to.MergedMaps(to.Flattened(nested), env))
+	fmt.Println(err)
+	fmt.Println(m["server"])
+	// Output:
+	// <nil>
+	// map[host:example.com ports:[80 map[tls:443] 8080]]
+}
+
+func ExampleUnflattened_overlayEmpty() {
+	base := map[string]any{"a": map[string]any{}, "b": []any{}}
+	m, err := to.Unflattened(to.MergedMaps(to.Flattened(base), map[string]any{"a.b": 1, "b.0": 2}))
+	fmt.Println(err)
+	fmt.Println(m)
+	fmt.Println(base)
+	leaf := map[string]any{"x": 1}
+	m, _ = to.Unflattened(map[string]any{"a": leaf, "a.y": 2})
+	fmt.Println(m, leaf)
+	// Output:
+	// <nil>
+	// map[a:map[b:1] b:[2]]
+	// map[a:map[] b:[]]
+	// map[a:map[x:1 y:2]] map[x:1]
+}
+
+func ExampleUnflattened_conflicts() {
+	_, err := to.Unflattened(map[string]any{"a": 1, "a.b": 2})
+	fmt.Println(err)
+	_, err = to.Unflattened(map[string]any{"a.b": 1, "a.0": 2})
+	fmt.Println(err)
+	_, err = to.Flattener{Brackets: true}.Unflatten(map[string]any{"a[x]": 1})
+	fmt.Println(err)
+	// Output:
+	// conflicting key "a.b" (key into non-map)
+	// conflicting key "a.b" (key into non-map)
+	// invalid index "x" in key "a[x]"
+}
@@ -6,4 +6,6 @@ require (
 	github.com/rwxrob/fn v0.3.3
 	github.com/rwxrob/pegn v0.1.0
 	github.com/rwxrob/structs v0.6.0
+	golang.org/x/net v0.17.0
+	golang.org/x/text v0.13.0
 )
@@ -0,0 +1,104 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import "strings"
+
+// Grid contains the configuration used to Render a list of items into
+// as many columns as will fit within Width the way ls does. Items are
+// placed down each column first (like ls) unless RowMajor is true.
+// Gutter is the minimum number of spaces between columns. MaxCols
+// limits the number of columns (zero for no limit). A Width less than
+// one is unlimited. All widths are calculated with DisplayWidth so that
+// items with terminal escapes (color) and wide runes still line up.
+type Grid struct {
+	Width    int
+	Gutter   int
+	MaxCols  int
+	RowMajor bool
+}
+
+// Gridded is shorthand for rendering the items into a column-major Grid
+// of the given width with a gutter of two spaces (see Grid.Render).
+func Gridded(items []string, width int) string {
+	return Grid{Width: width, Gutter: 2}.Render(items)
+}
+
+// Render returns the items laid out using the most columns that will
+// fit. Every line is trimmed of trailing white space and there is no
+// final line return. Items that are wider than the Width by themselves
+// are put in a single column.
+func (g Grid) Render(items []string) string {
+	if len(items) == 0 {
+		return ""
+	}
+	counts := make([]int, len(items))
+	for n, item := range items {
+		counts[n] = DisplayWidth(item)
+	}
+
+	max := len(items)
+	if g.MaxCols > 0 && g.MaxCols < max {
+		max = g.MaxCols
+	}
+
+	var rows, cols int
+	var widths []int
+	for cols = max; cols > 0; cols-- {
+		rows, widths = g.fit(counts, cols)
+		total := g.Gutter * (len(widths) - 1)
+		for _, w := range widths {
+			total += w
+		}
+		if g.Width < 1 || total <= g.Width || cols == 1 {
+			break
+		}
+	}
+	cols = len(widths)
+
+	lines := make([]string, rows)
+	for r := 0; r < rows; r++ {
+		var line strings.Builder
+		for c := 0; c < cols; c++ {
+			i := g.index(r, c, rows, cols)
+			if i >= len(items) {
+				continue
+			}
+			line.WriteString(items[i])
+			if c < cols-1 {
+				line.WriteString(strings.Repeat(" ", widths[c]-counts[i]+g.Gutter))
+			}
+		}
+		lines[r] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// index returns the index of the item at the given row and column.
+func (g Grid) index(row, col, rows, cols int) int {
+	if g.RowMajor {
+		return row*cols + col
+	}
+	return col*rows + row
+}
+
+// fit returns the number of rows and the width of every column needed
+// to lay out the items into no more than the given number of columns.
+func (g Grid) fit(counts []int, cols int) (int, []int) {
+	rows := (len(counts) + cols - 1) / cols
+	if !g.RowMajor {
+		cols = (len(counts) + rows - 1) / rows
+	}
+	widths := make([]int, cols)
+	for i, count := range counts {
+		c := i % cols
+		if !g.RowMajor {
+			c = i / rows
+		}
+		if count > widths[c] {
+			widths[c] = count
+		}
+	}
+	return rows, widths
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+var gridItems = []string{
+	"build", "completion", "config", "help", "init",
+	"install", "run", "test", "version",
+}
+
+func ExampleGridded() {
+	fmt.Println(to.Gridded(gridItems, 40))
+	// Output:
+	// build       help     run
+	// completion  init     test
+	// config      install  version
+}
+
+func ExampleGrid_Render_rowMajor() {
+	fmt.Println(to.Grid{Width: 40, Gutter: 2, RowMajor: true}.Render(gridItems))
+	// Output:
+	// build    completion  config  help
+	// init     install     run     test
+	// version
+}
+
+func ExampleGrid_Render_maxCols() {
+	fmt.Println(to.Grid{Width: 80, Gutter: 1, MaxCols: 2}.Render(gridItems[:5]))
+	// Output:
+	// build      help
+	// completion init
+	// config
+}
+
+func ExampleGrid_Render_narrow() {
+	fmt.Println(to.Gridded([]string{"something", "too", "wide"}, 5))
+	// Output:
+	// something
+	// too
+	// wide
+}
+
+func ExampleGrid_Render_escapes() {
+	items := []string{"\033[34mblue\033[0m", "red", "green", "x"}
+	fmt.Printf("%q\n", to.Gridded(items, 12))
+	// Output:
+	// "\x1b[34mblue\x1b[0m  green\nred   x"
+}
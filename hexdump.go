@@ -0,0 +1,239 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dumper contains the configuration used to Dump bytes as hexadecimal
+// text and Parse it back again. Width is the number of bytes on each
+// line (default 16) and Group the number of bytes in each group (default
+// 2, or 8 when Canonical). Base is the base of the offset at the start
+// of every line (16, 10, or 8, default 16). By default the layout is
+// that of xxd:
+//
+//	00000000: 4865 6c6c 6f2c 2077 6f72 6c64 210a       Hello, world!.
+//
+// If Canonical is true the layout is that of hexdump -C (with every byte
+// separated by a space, an extra space between groups, the ASCII
+// gutter within pipes, and a final line with the total length):
+//
+//	00000000  48 65 6c 6c 6f 2c 20 77  6f 72 6c 64 21 0a         |Hello, world!.|
+//	0000000e
+//
+// The ASCII gutter (omitted if NoASCII) shows every printable ASCII
+// byte as is and all others as a period. MaxGap limits how far past the
+// bytes parsed so far the offset of a line may be when parsing (default
+// 1 MiB) so that a single bad offset cannot exhaust memory.
+type Dumper struct {
+	Width     int
+	Group     int
+	Base      int
+	Canonical bool
+	NoASCII   bool
+	MaxGap    int
+}
+
+// HexDump is shorthand for Dumper{}.Dump.
+func HexDump(in any) string { return Dumper{}.Dump(in) }
+
+// ParseHexDump is shorthand for Dumper{}.Parse.
+func ParseHexDump(in any) ([]byte, error) { return Dumper{}.Parse(in) }
+
+func (d Dumper) defaults() Dumper {
+	if d.Width < 1 {
+		d.Width = 16
+	}
+	if d.Group < 1 {
+		d.Group = 2
+		if d.Canonical {
+			d.Group = 8
+		}
+	}
+	switch d.Base {
+	case 8, 10:
+	default:
+		d.Base = 16
+	}
+	if d.MaxGap < 1 {
+		d.MaxGap = 1 << 20
+	}
+	return d
+}
+
+func (d Dumper) offset(n int) string {
+	s := strconv.FormatInt(int64(n), d.Base)
+	if len(s) < 8 {
+		s = strings.Repeat("0", 8-len(s)) + s
+	}
+	return s
+}
+
+// gutter returns the bytes with every byte that is not printable ASCII
+// as a period.
+func gutter(b []byte) string {
+	out := make([]byte, len(b))
+	for n, c := range b {
+		if c < 0x20 || c > 0x7e {
+			c = '.'
+		}
+		out[n] = c
+	}
+	return string(out)
+}
+
+// hexLine returns the hex part of a single line (without padding).
+func (d Dumper) hexLine(b []byte) string {
+	var out strings.Builder
+	for n, c := range b {
+		switch {
+		case n == 0:
+		case d.Canonical && n%d.Group == 0:
+			out.WriteString("  ")
+		case d.Canonical || n%d.Group == 0:
+			out.WriteString(" ")
+		}
+		out.WriteString(hex.EncodeToString([]byte{c}))
+	}
+	return out.String()
+}
+
+// Dump returns the input (anything Bytes accepts) as hexadecimal text
+// (see Dumper). Repeated lines are never collapsed.
+func (d Dumper) Dump(in any) string {
+	d = d.defaults()
+	buf := Bytes(in)
+	full := make([]byte, d.Width)
+	hexwidth := len(d.hexLine(full))
+	var out strings.Builder
+	for n := 0; n < len(buf); n += d.Width {
+		end := n + d.Width
+		if end > len(buf) {
+			end = len(buf)
+		}
+		line := buf[n:end]
+		h := d.hexLine(line)
+		if d.Canonical {
+			out.WriteString(d.offset(n) + "  " + h)
+		} else {
+			out.WriteString(d.offset(n) + ": " + h)
+		}
+		if !d.NoASCII {
+			pad := strings.Repeat(" ", hexwidth-len(h))
+			if d.Canonical {
+				out.WriteString(pad + "  |" + gutter(line) + "|")
+			} else {
+				out.WriteString(pad + "  " + gutter(line))
+			}
+		}
+		out.WriteString("\n")
+	}
+	if d.Canonical && len(buf) > 0 {
+		out.WriteString(d.offset(len(buf)) + "\n")
+	}
+	return out.String()
+}
+
+// Parse returns the bytes of a hex dump (anything String accepts) in
+// either layout (see Dumper) or plain hex (xxd -p) with any white space.
+// A line is only taken to begin with an offset if it ends with a colon
+// (xxd) or has at least 8 digits or an ASCII gutter (hexdump -C).
+// Only the Base of the Dumper is used (to read the offsets). Like xxd -r
+// the bytes of every line are written at its offset (filling any gap
+// with zeros) and a line with a single asterisk (from hexdump) repeats
+// the line before it up to the offset of the next. Errors include the
+// line number.
+func (d Dumper) Parse(in any) ([]byte, error) {
+	d = d.defaults()
+	var out, last []byte
+	var repeat, canonical bool
+
+	put := func(off int, b []byte) {
+		if off > len(out) {
+			gap := make([]byte, off-len(out))
+			if repeat && len(last) > 0 {
+				for i := range gap {
+					gap[i] = last[i%len(last)]
+				}
+			}
+			out = append(out, gap...)
+		}
+		repeat = false
+		if end := off + len(b); end > len(out) {
+			out = append(out, make([]byte, end-len(out))...)
+		}
+		copy(out[off:], b)
+		last = b
+	}
+
+	for n, line := range Lines(in) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if trimmed == "*" {
+			repeat = true
+			continue
+		}
+
+		field, rest, _ := strings.Cut(trimmed, " ")
+		parseOffset := func(s string) (int, error) {
+			off, err := strconv.ParseUint(s, d.Base, 64)
+			if err != nil {
+				return 0, fmt.Errorf("line %v: invalid offset %q", n+1, field)
+			}
+			if off > uint64(len(out)+d.MaxGap) {
+				return 0, fmt.Errorf("line %v: offset %q is more than %v past the end", n+1, field, d.MaxGap)
+			}
+			return int(off), nil
+		}
+		var data string
+		offset := -1
+
+		switch {
+
+		// xxd
+		case strings.HasSuffix(field, ":"):
+			off, err := parseOffset(strings.TrimSuffix(field, ":"))
+			if err != nil {
+				return out, err
+			}
+			offset = off
+			data, _, _ = strings.Cut(strings.TrimLeft(rest, " "), "  ")
+
+		// hexdump -C (offsets are always at least 8 digits)
+		case strings.Contains(rest, "|") ||
+			len(field) >= 8 && (strings.HasPrefix(rest, " ") || rest == "" && canonical):
+			canonical = true
+			off, err := parseOffset(field)
+			if err != nil {
+				return out, err
+			}
+			offset = off
+			data, _, _ = strings.Cut(rest, "|")
+
+		// plain
+		default:
+			data = trimmed
+		}
+
+		data = strings.Join(strings.Fields(data), "")
+		if len(data)%2 != 0 {
+			return out, fmt.Errorf("line %v: odd number of hex digits", n+1)
+		}
+		b, err := hex.DecodeString(data)
+		if err != nil {
+			return out, fmt.Errorf("line %v: %v", n+1, err)
+		}
+		if offset < 0 {
+			offset = len(out)
+		}
+		put(offset, b)
+	}
+	return out, nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleHexDump() {
+	fmt.Print(to.HexDump("Hello, world!\nand \x00\xff more"))
+	// Output:
+	// 00000000: 4865 6c6c 6f2c 2077 6f72 6c64 210a 616e  Hello, world!.an
+	// 00000010: 6420 00ff 206d 6f72 65                   d .. more
+}
+
+func ExampleDumper_Dump() {
+	in := "Hello, world!\nand \x00\xff more"
+	fmt.Print(to.Dumper{Canonical: true}.Dump(in))
+	fmt.Print(to.Dumper{Width: 8, Group: 4, Base: 10}.Dump(in))
+	fmt.Print(to.Dumper{Width: 8, Group: 1, NoASCII: true}.Dump("abc"))
+	// Output:
+	// 00000000  48 65 6c 6c 6f 2c 20 77  6f 72 6c 64 21 0a 61 6e  |Hello, world!.an|
+	// 00000010  64 20 00 ff 20 6d 6f 72  65                       |d .. more|
+	// 00000019
+	// 00000000: 48656c6c 6f2c2077  Hello, w
+	// 00000008: 6f726c64 210a616e  orld!.an
+	// 00000016: 642000ff 206d6f72  d .. mor
+	// 00000024: 65                 e
+	// 00000000: 61 62 63
+}
+
+func ExampleParseHexDump() {
+	in := "Hello, world!\nand \x00\xff more"
+	for _, d := range []to.Dumper{{}, {Canonical: true}, {Width: 8, Group: 4}} {
+		b, err := to.ParseHexDump(d.Dump(in))
+		fmt.Printf("%q %v\n", b, err)
+	}
+
+	// xxd -p, offsets with gaps, and hexdump repeats
+	fmt.Println(to.ParseHexDump("48656c\n6c6f"))
+	fmt.Println(to.ParseHexDump("48656c6c6f48656c6c6f"))
+	fmt.Println(to.ParseHexDump("48  65\n6c   6c 6f"))
+	b, err := to.ParseHexDump("00000000: 4142  AB\n00000004: 4344  CD")
+	fmt.Printf("%q %v\n", b, err)
+	b, err = to.ParseHexDump("00000000  61 62  |ab|\n*\n00000006  63 |c|\n00000007")
+	fmt.Printf("%q %v\n", b, err)
+
+	_, err = to.ParseHexDump("00000000: 4g42  ..")
+	fmt.Println(err)
+	_, err = to.Dumper{Base: 10}.Parse("00000000: 41\nzz: 42")
+	fmt.Println(err)
+	_, err = to.ParseHexDump("ffffffff: 41")
+	fmt.Println(err)
+	_, err = to.Dumper{MaxGap: 4}.Parse("00000000: 41\n00000010: 42")
+	fmt.Println(err)
+	// Output:
+	// "Hello, world!\nand \x00\xff more" <nil>
+	// "Hello, world!\nand \x00\xff more" <nil>
+	// "Hello, world!\nand \x00\xff more" <nil>
+	// [72 101 108 108 111] <nil>
+	// [72 101 108 108 111 72 101 108 108 111] <nil>
+	// [72 101 108 108 111] <nil>
+	// "AB\x00\x00CD" <nil>
+	// "abababc" <nil>
+	// line 1: encoding/hex: invalid byte: U+0067 'g'
+	// line 2: invalid offset "zz:"
+	// line 1: offset "ffffffff:" is more than 1048576 past the end
+	// line 2: offset "00000010:" is more than 4 past the end
+}
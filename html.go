@@ -0,0 +1,272 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// HTMLConv contains the configuration used to Convert text containing
+// terminal escape sequences (see RuneCount and Unescaped) into HTML.
+// By default every styled run of text is wrapped in a span with an
+// inline style attribute. If Classes is true, CSS classes (beginning
+// with Prefix, default "ansi-") are used instead (except for 24-bit
+// colors which are always inline):
+//
+//	ansi-fg-N ansi-bg-N (N is 0-255)
+//	ansi-bold ansi-dim ansi-italic ansi-underline ansi-strike
+//	ansi-reverse
+type HTMLConv struct {
+	Classes bool
+	Prefix  string
+}
+
+// HTML is shorthand for HTMLConv{}.Convert.
+func HTML(in string) string { return HTMLConv{}.Convert(in) }
+
+// sgrColor is either unset, an indexed (16 or 256) color, or a 24-bit
+// RGB color.
+type sgrColor struct {
+	set     bool
+	rgb     bool
+	index   int
+	r, g, b uint8
+}
+
+func (c sgrColor) hex() string {
+	if !c.rgb {
+		return XTerm(c.index).Hex()
+	}
+	return RGB{c.r, c.g, c.b}.Hex()
+}
+
+// sgrState is the current Select Graphic Rendition.
+type sgrState struct {
+	fg, bg                                sgrColor
+	bold, dim, italic, under, rev, strike bool
+}
+
+// apply updates the state from the parameters of an SGR sequence.
+func (s *sgrState) apply(params string) {
+	fields := strings.FieldsFunc(params, func(r rune) bool { return r == ';' || r == ':' })
+	if len(fields) == 0 || params == "" {
+		fields = []string{"0"}
+	}
+	codes := make([]int, len(fields))
+	for n, f := range fields {
+		codes[n], _ = strconv.Atoi(f)
+	}
+	for n := 0; n < len(codes); n++ {
+		switch c := codes[n]; {
+		case c == 0:
+			*s = sgrState{}
+		case c == 1:
+			s.bold = true
+		case c == 2:
+			s.dim = true
+		case c == 3:
+			s.italic = true
+		case c == 4:
+			s.under = true
+		case c == 7:
+			s.rev = true
+		case c == 9:
+			s.strike = true
+		case c == 21 || c == 22:
+			s.bold, s.dim = false, false
+		case c == 23:
+			s.italic = false
+		case c == 24:
+			s.under = false
+		case c == 27:
+			s.rev = false
+		case c == 29:
+			s.strike = false
+		case c >= 30 && c <= 37:
+			s.fg = sgrColor{set: true, index: c - 30}
+		case c >= 40 && c <= 47:
+			s.bg = sgrColor{set: true, index: c - 40}
+		case c >= 90 && c <= 97:
+			s.fg = sgrColor{set: true, index: c - 90 + 8}
+		case c >= 100 && c <= 107:
+			s.bg = sgrColor{set: true, index: c - 100 + 8}
+		case c == 39:
+			s.fg = sgrColor{}
+		case c == 49:
+			s.bg = sgrColor{}
+		case c == 38 || c == 48:
+			var col sgrColor
+			switch {
+			case n+2 < len(codes) && codes[n+1] == 5:
+				col = sgrColor{set: true, index: codes[n+2] & 0xff}
+				n += 2
+			case n+4 < len(codes) && codes[n+1] == 2:
+				col = sgrColor{set: true, rgb: true,
+					r: uint8(codes[n+2]), g: uint8(codes[n+3]), b: uint8(codes[n+4])}
+				n += 4
+			default:
+				n = len(codes)
+				continue
+			}
+			if c == 38 {
+				s.fg = col
+			} else {
+				s.bg = col
+			}
+		}
+	}
+}
+
+// attrs returns the class and style attributes for the state.
+func (h HTMLConv) attrs(s sgrState) string {
+	fg, bg := s.fg, s.bg
+	if s.rev {
+		fg, bg = bg, fg
+	}
+	var classes, styles []string
+	pre := h.Prefix
+	if pre == "" {
+		pre = "ansi-"
+	}
+
+	color := func(c sgrColor, kind, prop string) {
+		if !c.set {
+			return
+		}
+		if h.Classes && !c.rgb {
+			classes = append(classes, pre+kind+"-"+strconv.Itoa(c.index))
+			return
+		}
+		styles = append(styles, prop+":"+c.hex())
+	}
+	color(fg, "fg", "color")
+	color(bg, "bg", "background-color")
+
+	if !h.Classes && s.rev {
+		if !fg.set {
+			styles = append(styles, "color:Canvas")
+		}
+		if !bg.set {
+			styles = append(styles, "background-color:CanvasText")
+		}
+	}
+
+	flags := []struct {
+		on    bool
+		class string
+		style string
+	}{
+		{s.bold, "bold", "font-weight:bold"},
+		{s.dim, "dim", "opacity:.5"},
+		{s.italic, "italic", "font-style:italic"},
+		{s.rev, "reverse", ""},
+	}
+	for _, f := range flags {
+		if !f.on {
+			continue
+		}
+		switch {
+		case h.Classes:
+			classes = append(classes, pre+f.class)
+		case f.style != "":
+			styles = append(styles, f.style)
+		}
+	}
+	var decor []string
+	if s.under {
+		decor = append(decor, "underline")
+		if h.Classes {
+			classes = append(classes, pre+"underline")
+		}
+	}
+	if s.strike {
+		decor = append(decor, "line-through")
+		if h.Classes {
+			classes = append(classes, pre+"strike")
+		}
+	}
+	if !h.Classes && len(decor) > 0 {
+		styles = append(styles, "text-decoration:"+strings.Join(decor, " "))
+	}
+
+	var attrs string
+	if len(classes) > 0 {
+		attrs += ` class="` + strings.Join(classes, " ") + `"`
+	}
+	if len(styles) > 0 {
+		attrs += ` style="` + strings.Join(styles, ";") + `"`
+	}
+	return attrs
+}
+
+// safeHref returns true if the URI is http, https, mailto, or relative
+// and contains no white space or control characters (which browsers
+// strip, hiding schemes like java\tscript:).
+func safeHref(uri string) bool {
+	if uri == "" || strings.IndexFunc(uri, func(r rune) bool {
+		return r <= ' ' || r == 0x7f
+	}) >= 0 {
+		return false
+	}
+	switch scheme := strings.ToLower(hasscheme.FindString(uri)); scheme {
+	case "", "http:", "https:", "mailto:":
+		return true
+	}
+	return false
+}
+
+// Convert returns the input as HTML with all text escaped (see
+// html.EscapeString) and every run of text with the same SGR styling
+// (color, bold, italic, underline, strike, reverse) wrapped in a span.
+// 16, 256 (see XTerm), and 24-bit colors are all supported. OSC 8
+// hyperlinks become anchors (a) but only for http, https, mailto, and
+// relative URIs (the text of any other link is kept as plain text).
+// All other escape sequences are dropped. White space (including line
+// returns) is kept as is so the result is usually placed within a pre
+// element.
+func (h HTMLConv) Convert(in string) string {
+	var out strings.Builder
+	var state sgrState
+	var inlink bool
+	var last int
+	text := func(t string) {
+		if t == "" {
+			return
+		}
+		t = html.EscapeString(t)
+		attrs := h.attrs(state)
+		if attrs == "" {
+			out.WriteString(t)
+			return
+		}
+		out.WriteString("<span" + attrs + ">" + t + "</span>")
+	}
+	for _, e := range Escapes(in) {
+		text(in[last:e.Offset])
+		last = e.Offset + len(e.Seq)
+		seq := e.Seq
+		switch {
+		case strings.HasPrefix(seq, "\033[") && strings.HasSuffix(seq, "m"):
+			state.apply(seq[2 : len(seq)-1])
+		case strings.HasPrefix(seq, "\033]8;"):
+			body := strings.TrimSuffix(strings.TrimSuffix(seq[4:], "\a"), "\033\\")
+			_, uri, _ := strings.Cut(body, ";")
+			if inlink {
+				out.WriteString("</a>")
+				inlink = false
+			}
+			if safeHref(uri) {
+				out.WriteString(`<a href="` + html.EscapeString(uri) + `">`)
+				inlink = true
+			}
+		}
+	}
+	text(in[last:])
+	if inlink {
+		out.WriteString("</a>")
+	}
+	return out.String()
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleHTML() {
+	fmt.Println(to.HTML("plain <b> & \"quotes\""))
+	fmt.Println(to.HTML("\033[31mred\033[0m and \033[1;4;94mbold bright blue\033[0m"))
+	fmt.Println(to.HTML("\033[38;5;208morange\033[48;2;0;0;0m on black\033[m"))
+	fmt.Println(to.HTML("\033[3mitalic \033[9mstruck\033[23;29m normal"))
+	fmt.Println(to.HTML("\033[7mreversed\033[27m \033[7;32;40mgreen\033[0m"))
+	// Output:
+	// plain &lt;b&gt; &amp; &#34;quotes&#34;
+	// <span style="color:#cd0000">red</span> and <span style="color:#5c5cff;font-weight:bold;text-decoration:underline">bold bright blue</span>
+	// <span style="color:#ff8700">orange</span><span style="color:#ff8700;background-color:#000000"> on black</span>
+	// <span style="font-style:italic">italic </span><span style="font-style:italic;text-decoration:line-through">struck</span> normal
+	// <span style="color:Canvas;background-color:CanvasText">reversed</span> <span style="color:#000000;background-color:#00cd00">green</span>
+}
+
+func ExampleHTML_links() {
+	fmt.Println(to.HTML("see \033]8;;https://rwx.gg/?a=1&b=2\033\\\033[34mrwx.gg\033[0m\033]8;;\033\\ now"))
+	fmt.Println(to.HTML("\033]0;window title\a\033[2Jcleared"))
+	fmt.Println(to.HTML("\033]8;;javascript:alert(1)\033\\click\033]8;;\033\\"))
+	fmt.Println(to.HTML("\033]8;;JAVA\tSCRIPT:alert(1)\aclick\033]8;;\a"))
+	fmt.Println(to.HTML("\033]8;;../docs?a=<b>\aread\033]8;;\a \033]8;;MAILTO:rob@rwx.gg\amail\033]8;;\a"))
+	// Output:
+	// see <a href="https://rwx.gg/?a=1&amp;b=2"><span style="color:#0000ee">rwx.gg</span></a> now
+	// cleared
+	// click
+	// click
+	// <a href="../docs?a=&lt;b&gt;">read</a> <a href="MAILTO:rob@rwx.gg">mail</a>
+}
+
+func ExampleHTMLConv_Convert() {
+	h := to.HTMLConv{Classes: true}
+	fmt.Println(h.Convert("\033[1;31mbold red\033[0m \033[38;5;208;48;2;1;2;3mmixed\033[0m"))
+	fmt.Println(to.HTMLConv{Classes: true, Prefix: "t-"}.Convert("\033[7;4;32mrev\033[0m"))
+	// Output:
+	// <span class="ansi-fg-1 ansi-bold">bold red</span> <span class="ansi-fg-208" style="background-color:#010203">mixed</span>
+	// <span class="t-bg-2 t-reverse t-underline">rev</span>
+}
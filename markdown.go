@@ -0,0 +1,661 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MarkdownConv contains the configuration used to Convert Markdown into
+// wrapped and indented text for the terminal. Width is the maximum
+// width of the wrapped text (see Wrapped). If Plain is true no terminal
+// escapes are added (emphasis markers are dropped and links are
+// followed by their URL in parentheses).
+type MarkdownConv struct {
+	Width int
+	Plain bool
+}
+
+// Rendered is shorthand for MarkdownConv{Width: width}.Convert.
+func Rendered(markdown string, width int) string {
+	return MarkdownConv{Width: width}.Convert(markdown)
+}
+
+// mdKind is the kind of a Markdown block.
+type mdKind int
+
+const (
+	mdPara mdKind = iota
+	mdHeading
+	mdCode
+	mdQuote
+	mdList
+	mdRule
+	mdTable
+)
+
+// mdBlock is a single parsed Markdown block. Paragraphs and headings
+// keep their (unparsed inline) lines, block quotes their children, and
+// lists their items (each a slice of blocks).
+type mdBlock struct {
+	kind     mdKind
+	level    int
+	lines    []string
+	children []mdBlock
+	items    [][]mdBlock
+	ordered  bool
+	start    int
+	tight    bool
+	rows     [][]string
+	align    []Align
+}
+
+var (
+	mdATXRe      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdSetextRe   = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdRuleRe     = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdFenceRe    = regexp.MustCompile("^( {0,3})(```+|~~~+)(.*)$")
+	mdQuoteRe    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	mdItemRe     = regexp.MustCompile(`^( {0,3})([-*+]|[0-9]{1,9}[.)])(?:( +)(.*))?$`)
+	mdTableSepRe = regexp.MustCompile(`^ {0,3}\|?(?:[ \t]*:?-+:?[ \t]*\|)*[ \t]*:?-+:?[ \t]*\|?[ \t]*$`)
+)
+
+// mdStarts returns true if the line begins any block other than
+// a paragraph (and can therefore interrupt one).
+func mdStarts(line string) bool {
+	return mdFenceRe.MatchString(line) || mdATXRe.MatchString(line) ||
+		mdRuleRe.MatchString(line) || mdQuoteRe.MatchString(line) ||
+		mdItemRe.MatchString(line)
+}
+
+// mdIndent returns the number of leading spaces.
+func mdIndent(line string) int { return len(line) - len(strings.TrimLeft(line, " ")) }
+
+// mdParse parses the lines (with tabs already expanded) into blocks.
+func mdParse(lines []string) []mdBlock {
+	var blocks []mdBlock
+	for n := 0; n < len(lines); {
+		line := lines[n]
+		switch {
+
+		case isblank.MatchString(line):
+			n++
+
+		case mdFenceRe.MatchString(line):
+			m := mdFenceRe.FindStringSubmatch(line)
+			indent, fence := len(m[1]), m[2]
+			var code []string
+			for n++; n < len(lines); n++ {
+				l := strings.TrimSpace(lines[n])
+				if strings.HasPrefix(l, fence) && strings.Trim(l, fence[:1]) == "" {
+					n++
+					break
+				}
+				// each line loses as much of the fence indent as it has
+				l = lines[n]
+				cut := indent
+				if i := mdIndent(l); i < cut {
+					cut = i
+				}
+				code = append(code, l[cut:])
+			}
+			blocks = append(blocks, mdBlock{kind: mdCode, lines: code})
+
+		case mdATXRe.MatchString(line):
+			m := mdATXRe.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: mdHeading, level: len(m[1]), lines: []string{m[2]}})
+			n++
+
+		case mdRuleRe.MatchString(line):
+			blocks = append(blocks, mdBlock{kind: mdRule})
+			n++
+
+		case mdQuoteRe.MatchString(line):
+			var inner []string
+			for n < len(lines) {
+				if m := mdQuoteRe.FindStringSubmatch(lines[n]); m != nil {
+					inner = append(inner, m[1])
+					n++
+					continue
+				}
+				// lazy continuation of a quoted paragraph
+				if isblank.MatchString(lines[n]) || mdStarts(lines[n]) ||
+					isblank.MatchString(inner[len(inner)-1]) {
+					break
+				}
+				inner = append(inner, lines[n])
+				n++
+			}
+			blocks = append(blocks, mdBlock{kind: mdQuote, children: mdParse(inner)})
+
+		case mdItemRe.MatchString(line):
+			var list mdBlock
+			list, n = mdParseList(lines, n)
+			blocks = append(blocks, list)
+
+		case n+1 < len(lines) && strings.Contains(line, "|") &&
+			strings.Contains(lines[n+1], "|") && mdTableSepRe.MatchString(lines[n+1]):
+			table := mdBlock{kind: mdTable, rows: [][]string{mdCells(line)}}
+			for _, c := range mdCells(lines[n+1]) {
+				switch {
+				case strings.HasPrefix(c, ":") && strings.HasSuffix(c, ":"):
+					table.align = append(table.align, AlignCenter)
+				case strings.HasSuffix(c, ":"):
+					table.align = append(table.align, AlignRight)
+				default:
+					table.align = append(table.align, AlignLeft)
+				}
+			}
+			for n += 2; n < len(lines) && strings.Contains(lines[n], "|") &&
+				!isblank.MatchString(lines[n]); n++ {
+				table.rows = append(table.rows, mdCells(lines[n]))
+			}
+			blocks = append(blocks, table)
+
+		default:
+			para := mdBlock{kind: mdPara}
+			for n < len(lines) && !isblank.MatchString(lines[n]) {
+				if m := mdSetextRe.FindStringSubmatch(lines[n]); m != nil && len(para.lines) > 0 {
+					para.kind, para.level = mdHeading, 2
+					if m[1][0] == '=' {
+						para.level = 1
+					}
+					n++
+					break
+				}
+				if len(para.lines) > 0 && mdStarts(lines[n]) {
+					break
+				}
+				para.lines = append(para.lines, strings.TrimLeft(lines[n], " "))
+				n++
+			}
+			blocks = append(blocks, para)
+		}
+	}
+	return blocks
+}
+
+// mdParseList parses the list beginning at line n and returns it with
+// the index of the first line following it.
+func mdParseList(lines []string, n int) (mdBlock, int) {
+	first := mdItemRe.FindStringSubmatch(lines[n])
+	marker := func(m string) string {
+		if m[0] >= '0' && m[0] <= '9' {
+			return m[len(m)-1:]
+		}
+		return m
+	}
+	list := mdBlock{kind: mdList, tight: true}
+	if m := first[2]; marker(m) != m {
+		list.ordered = true
+		list.start, _ = strconv.Atoi(m[:len(m)-1])
+	}
+	for n < len(lines) {
+		m := mdItemRe.FindStringSubmatch(lines[n])
+		if m == nil || marker(m[2]) != marker(first[2]) {
+			break
+		}
+		indent := len(m[1]) + len(m[2]) + 1
+		if s := len(m[3]); s > 0 && s <= 4 {
+			indent = len(m[1]) + len(m[2]) + s
+		}
+		item := []string{m[4]}
+		for n++; n < len(lines); n++ {
+			line := lines[n]
+			switch {
+			case isblank.MatchString(line):
+				item = append(item, "")
+				continue
+			case mdIndent(line) >= indent:
+				item = append(item, line[indent:])
+				continue
+			case item[len(item)-1] != "" && !mdStarts(line):
+				item = append(item, strings.TrimLeft(line, " "))
+				continue
+			}
+			break
+		}
+		for len(item) > 0 && item[len(item)-1] == "" {
+			item = item[:len(item)-1]
+			if n < len(lines) {
+				if next := mdItemRe.FindStringSubmatch(lines[n]); next != nil &&
+					marker(next[2]) == marker(first[2]) {
+					list.tight = false
+				}
+			}
+		}
+		for _, l := range item {
+			if l == "" {
+				list.tight = false
+			}
+		}
+		list.items = append(list.items, mdParse(item))
+	}
+	return list, n
+}
+
+// mdCells splits a table row into trimmed cells on every unescaped pipe.
+func mdCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// mdSpan is the kind of an inline Markdown span.
+type mdSpan int
+
+const (
+	mdStrong mdSpan = iota
+	mdEmph
+	mdStrike
+	mdCodeSpan
+	mdLink
+	mdImage
+)
+
+const mdPunct = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+func mdAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// mdRun returns the number of times c repeats at the start of s.
+func mdRun(s string, c byte) int {
+	var n int
+	for n < len(s) && s[n] == c {
+		n++
+	}
+	return n
+}
+
+// mdClose returns the index of the delimiter closing an emphasis span
+// within s or -1 if there is none.
+func mdClose(s, delim string) int {
+	d := len(delim)
+	for k := 1; k+d <= len(s); k++ {
+		if s[k:k+d] != delim || s[k-1] == ' ' || s[k-1] == delim[0] {
+			continue
+		}
+		if k+d < len(s) && (s[k+d] == delim[0] || delim[0] == '_' && mdAlnum(s[k+d])) {
+			continue
+		}
+		return k
+	}
+	return -1
+}
+
+// mdBracket returns the index of the bracket closing the one at i or -1.
+func mdBracket(s string, i int) int {
+	var depth int
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// mdInline returns the inline Markdown with every span (already
+// rendered within) passed through style (with the url of links and
+// images) and all other literal text through lit.
+func mdInline(in string, style func(span mdSpan, text, url string) string, lit func(string) string) string {
+	var out, plain strings.Builder
+	flush := func() {
+		if plain.Len() > 0 {
+			out.WriteString(lit(plain.String()))
+			plain.Reset()
+		}
+	}
+	for i := 0; i < len(in); {
+		c := in[i]
+		switch {
+
+		case c == '\\' && i+1 < len(in) && strings.IndexByte(mdPunct, in[i+1]) >= 0:
+			plain.WriteByte(in[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			run := mdRun(in[i:], c)
+			if end := strings.Index(in[i+run:], in[i:i+run]); end >= 0 {
+				code := in[i+run : i+run+end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				flush()
+				out.WriteString(style(mdCodeSpan, lit(code), ""))
+				i += 2*run + end
+				continue
+			}
+			plain.WriteString(in[i : i+run])
+			i += run
+			continue
+
+		case c == '*' || c == '_' || c == '~':
+			run := mdRun(in[i:], c)
+			delim := in[i : i+run]
+			open := run <= 3 && (c != '~' || run == 2) &&
+				i+run < len(in) && in[i+run] != ' ' &&
+				!(c == '_' && i > 0 && mdAlnum(in[i-1]))
+			if end := mdClose(in[i+run:], delim); open && end >= 0 {
+				text := mdInline(in[i+run:i+run+end], style, lit)
+				flush()
+				switch {
+				case c == '~':
+					text = style(mdStrike, text, "")
+				case run == 1:
+					text = style(mdEmph, text, "")
+				case run == 2:
+					text = style(mdStrong, text, "")
+				default:
+					text = style(mdEmph, style(mdStrong, text, ""), "")
+				}
+				out.WriteString(text)
+				i += 2*run + end
+				continue
+			}
+			plain.WriteString(delim)
+			i += run
+			continue
+
+		case c == '[' || c == '!' && i+1 < len(in) && in[i+1] == '[':
+			open := i
+			if c == '!' {
+				open++
+			}
+			close := mdBracket(in, open)
+			if close < 0 || close+1 >= len(in) || in[close+1] != '(' {
+				break
+			}
+			end := strings.IndexByte(in[close+2:], ')')
+			if end < 0 {
+				break
+			}
+			url := strings.TrimSpace(in[close+2 : close+2+end])
+			if k := strings.IndexAny(url, " \t"); k >= 0 {
+				url = url[:k]
+			}
+			url = strings.TrimSuffix(strings.TrimPrefix(url, "<"), ">")
+			text := mdInline(in[open+1:close], style, lit)
+			span := mdLink
+			if c == '!' {
+				span = mdImage
+			}
+			flush()
+			out.WriteString(style(span, text, url))
+			i = close + 3 + end
+			continue
+
+		case c == '<':
+			end := strings.IndexByte(in[i:], '>')
+			if end < 0 {
+				break
+			}
+			url := in[i+1 : i+end]
+			if strings.ContainsAny(url, " \t") ||
+				!strings.Contains(url, "://") && !strings.Contains(url, "@") {
+				break
+			}
+			flush()
+			out.WriteString(style(mdLink, lit(url), url))
+			i += end + 1
+			continue
+		}
+		plain.WriteByte(c)
+		i++
+	}
+	flush()
+	return out.String()
+}
+
+// style returns the text of the inline span with terminal escapes that
+// only turn off what they turn on so that spans can be nested.
+func (m MarkdownConv) style(span mdSpan, text, url string) string {
+	var link string
+	if url != "" && Unescaped(text) != url {
+		link = " (" + url + ")"
+	}
+	if m.Plain {
+		if span == mdLink || span == mdImage {
+			return text + link
+		}
+		return text
+	}
+	switch span {
+	case mdStrong:
+		return "\033[1m" + text + "\033[22m"
+	case mdEmph:
+		return "\033[3m" + text + "\033[23m"
+	case mdStrike:
+		return "\033[9m" + text + "\033[29m"
+	case mdCodeSpan:
+		return "\033[36m" + text + "\033[39m"
+	}
+	return "\033[4m" + text + "\033[24m" + link
+}
+
+// Convert returns the Markdown rendered for the terminal. The following
+// subset of CommonMark (with GitHub tables and strikethrough) is
+// supported:
+//
+//   - ATX (#) and setext (=== or ---) headings
+//   - paragraphs (wrapped to Width) with hard line breaks
+//   - emphasis, strong emphasis, strikethrough, and inline code
+//   - links, autolinks (<https://...>), and images (as links)
+//   - block quotes (prefixed with │ or > when Plain)
+//   - bulleted and numbered lists (nested, tight, or loose)
+//   - fenced code blocks (indented four spaces and never wrapped)
+//   - thematic breaks (a rule the full Width)
+//   - tables (see Table, never wrapped)
+//
+// Everything else (including raw HTML) is treated as paragraph text.
+// Tabs are expanded to four spaces. The result always ends with a line
+// return unless empty.
+func (m MarkdownConv) Convert(in string) string {
+	in = strings.ReplaceAll(in, "\t", "    ")
+	out := m.blocks(mdParse(Lines(in)), m.Width, false)
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+func (m MarkdownConv) inline(in string) string {
+	return mdInline(in, m.style, func(s string) string { return s })
+}
+
+// blocks renders the blocks separated by blank lines (unless tight).
+func (m MarkdownConv) blocks(blocks []mdBlock, width int, tight bool) string {
+	sep := "\n\n"
+	if tight {
+		sep = "\n"
+	}
+	var parts []string
+	for _, b := range blocks {
+		if part := m.block(b, width); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// mdPrefixed adds the first prefix to the first line and the rest
+// prefix to every other line without adding trailing spaces to blank
+// lines.
+func mdPrefixed(in, first, rest string) string {
+	lines := Lines(in)
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+	for n, line := range lines {
+		pre := rest
+		if n == 0 {
+			pre = first
+		}
+		lines[n] = strings.TrimRight(pre+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m MarkdownConv) block(b mdBlock, width int) string {
+	switch b.kind {
+
+	case mdHeading:
+		text := m.para(b.lines, width)
+		if text == "" {
+			return ""
+		}
+		if m.Plain {
+			var w int
+			for _, line := range Lines(text) {
+				if n := DisplayWidth(line); n > w {
+					w = n
+				}
+			}
+			switch b.level {
+			case 1:
+				text += "\n" + strings.Repeat("=", w)
+			case 2:
+				text += "\n" + strings.Repeat("-", w)
+			}
+			return text
+		}
+		esc := "\033[1m"
+		if b.level == 1 {
+			esc = "\033[1;4m"
+		}
+		lines := Lines(text)
+		for n, line := range lines {
+			lines[n] = esc + line + "\033[0m"
+		}
+		return strings.Join(lines, "\n")
+
+	case mdCode:
+		lines := make([]string, len(b.lines))
+		for n, line := range b.lines {
+			switch {
+			case line == "":
+			case m.Plain:
+				lines[n] = "    " + line
+			default:
+				lines[n] = "    \033[36m" + line + "\033[39m"
+			}
+		}
+		return strings.Join(lines, "\n")
+
+	case mdRule:
+		w := width
+		if w < 1 {
+			w = 3
+		}
+		if m.Plain {
+			return strings.Repeat("-", w)
+		}
+		return strings.Repeat("─", w)
+
+	case mdQuote:
+		pre := "│ "
+		if m.Plain {
+			pre = "> "
+		}
+		return mdPrefixed(m.blocks(b.children, width-2, false), pre, pre)
+
+	case mdList:
+		mw := 2
+		if b.ordered {
+			mw = len(strconv.Itoa(b.start+len(b.items)-1)) + 2
+		}
+		items := make([]string, len(b.items))
+		for n, item := range b.items {
+			marker := "• "
+			switch {
+			case b.ordered:
+				marker = PadLeft(strconv.Itoa(b.start+n)+".", mw-1, ' ') + " "
+			case m.Plain:
+				marker = "- "
+			}
+			body := m.blocks(item, width-mw, b.tight)
+			items[n] = mdPrefixed(body, marker, strings.Repeat(" ", mw))
+		}
+		if b.tight {
+			return strings.Join(items, "\n")
+		}
+		return strings.Join(items, "\n\n")
+
+	case mdTable:
+		t := Table{Style: BoxTable, Align: b.align}
+		if m.Plain {
+			t.Style = ASCIITable
+		}
+		rows := make([][]string, len(b.rows))
+		for r, row := range b.rows {
+			rows[r] = make([]string, len(row))
+			for c, cell := range row {
+				rows[r][c] = m.inline(cell)
+				if r == 0 && !m.Plain && cell != "" {
+					rows[r][c] = "\033[1m" + rows[r][c] + "\033[22m"
+				}
+			}
+		}
+		t.Headers = rows[0]
+		out, _ := t.Render(rows[1:])
+		return out
+	}
+
+	return m.para(b.lines, width)
+}
+
+// para returns the lines joined, rendered, and wrapped keeping any hard
+// line breaks (two trailing spaces or a backslash).
+func (m MarkdownConv) para(lines []string, width int) string {
+	var segs, cur []string
+	for n, line := range lines {
+		hard := n < len(lines)-1 &&
+			(strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`))
+		if hard {
+			line = strings.TrimSuffix(strings.TrimRight(line, " "), `\`)
+		}
+		cur = append(cur, line)
+		if hard {
+			segs = append(segs, strings.Join(cur, " "))
+			cur = nil
+		}
+	}
+	segs = append(segs, strings.Join(cur, " "))
+	for n, seg := range segs {
+		if seg = m.inline(seg); strings.TrimSpace(seg) == "" {
+			segs[n] = ""
+			continue
+		}
+		segs[n], _ = Wrapped(seg, width)
+	}
+	return strings.Join(segs, "\n")
+}
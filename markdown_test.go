@@ -0,0 +1,121 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleRendered() {
+	in := "# Usage\n\nRun **foo** with `--all` to see *everything*."
+	fmt.Printf("%q\n", to.Rendered(in, 30))
+	// Output:
+	// "\x1b[1;4mUsage\x1b[0m\n\nRun \x1b[1mfoo\x1b[22m with \x1b[36m--all\x1b[39m to see\n\x1b[3meverything\x1b[23m.\n"
+}
+
+func ExampleMarkdownConv_Convert() {
+	in := `
+Title
+=====
+
+Some *emphasis*, **strong**, ~~gone~~, and ` + "`code`" + ` with
+a [link](https://rwx.gg) and <https://go.dev>. A hard  
+break.
+
+## Lists
+
+- one
+- two
+  1. nested
+  2. nested
+- three
+
+> quoted **text**
+continued lazily
+
+` + "```\nfunc main() {\n    fmt.Println(1)\n}\n```" + `
+
+---
+
+| Name | Qty |
+|:-----|----:|
+| apple | 3 |
+| pear \| x | 10 |
+`
+	fmt.Print(to.MarkdownConv{Width: 30, Plain: true}.Convert(in))
+	// Output:
+	// Title
+	// =====
+	//
+	// Some emphasis, strong, gone,
+	// and code with a link
+	// (https://rwx.gg) and
+	// https://go.dev. A hard
+	// break.
+	//
+	// Lists
+	// -----
+	//
+	// - one
+	// - two
+	//   1. nested
+	//   2. nested
+	// - three
+	//
+	// > quoted text continued lazily
+	//
+	//     func main() {
+	//         fmt.Println(1)
+	//     }
+	//
+	// ------------------------------
+	//
+	// +----------+-----+
+	// | Name     | Qty |
+	// +----------+-----+
+	// | apple    |   3 |
+	// | pear | x |  10 |
+	// +----------+-----+
+}
+
+func ExampleMarkdownConv_Convert_loose() {
+	in := "1. first item wraps around\n\n   second paragraph\n\n2. second\n\n> - quoted\n> - list"
+	fmt.Print(to.MarkdownConv{Width: 20, Plain: true}.Convert(in))
+	fmt.Print(to.MarkdownConv{Width: 20}.Convert("> quoted\n\n---"))
+	// Output:
+	// 1. first item wraps
+	//    around
+	//
+	//    second paragraph
+	//
+	// 2. second
+	//
+	// > - quoted
+	// > - list
+	// │ quoted
+	//
+	// ────────────────────
+}
+
+func ExampleMarkdownConv_Convert_empty() {
+	fmt.Printf("%q\n", to.Rendered("#\n", 40))
+	fmt.Printf("%q\n", to.Rendered("Intro\n\n##\n", 40))
+	fmt.Print(to.MarkdownConv{Width: 40, Plain: true}.Convert("one\\\n\\\ntwo  \n  \nthree"))
+	// Output:
+	// ""
+	// "Intro\n"
+	// one
+	//
+	// two
+	//
+	// three
+}
+
+func ExampleMarkdownConv_Convert_fence() {
+	fmt.Printf("%q\n", to.MarkdownConv{Width: 40, Plain: true}.Convert("  ```\n  a\n b\n  c\n   d\n  ```"))
+	// Output:
+	// "    a\n    b\n    c\n     d\n"
+}
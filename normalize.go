@@ -0,0 +1,107 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NFC returns the input in Unicode Normalization Form C (canonical
+// decomposition followed by canonical composition). This is the form
+// to use before comparing strings that only need to look the same.
+func NFC[T Text](in T) string { return norm.NFC.String(string(in)) }
+
+// NFD returns the input in Unicode Normalization Form D (canonical
+// decomposition).
+func NFD[T Text](in T) string { return norm.NFD.String(string(in)) }
+
+// NFKC returns the input in Unicode Normalization Form KC
+// (compatibility decomposition followed by canonical composition) which
+// also folds things like ligatures (ﬁ) and full-width letters.
+func NFKC[T Text](in T) string { return norm.NFKC.String(string(in)) }
+
+// NFKD returns the input in Unicode Normalization Form KD
+// (compatibility decomposition).
+func NFKD[T Text](in T) string { return norm.NFKD.String(string(in)) }
+
+// translits contains the transliterations for letters that do not
+// decompose into an ASCII letter and combining marks.
+var translits = map[rune]string{
+	'ß': "ss", 'ẞ': "SS", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+	'ø': "o", 'Ø': "O", 'đ': "d", 'Đ': "D", 'ð': "d", 'Ð': "D",
+	'þ': "th", 'Þ': "TH", 'ł': "l", 'Ł': "L", 'ı': "i", 'ħ': "h",
+	'Ħ': "H", 'ŋ': "ng", 'Ŋ': "NG", 'ĸ': "k", 'ſ': "s",
+	'‘': "'", '’': "'", '‚': "'", '“': `"`, '”': `"`, '„': `"`,
+	'–': "-", '—': "-", '…': "...", '•': "*", '\u00a0': " ",
+}
+
+// ASCIIFolded returns the input with every rune folded to its closest
+// ASCII equivalent by first applying NFKD and then dropping all
+// combining marks (Café becomes Cafe) and transliterating the letters
+// and punctuation that do not decompose (ß becomes ss). Anything that
+// still is not ASCII is dropped.
+func ASCIIFolded[T Text](in T) string {
+	var out strings.Builder
+	for _, r := range norm.NFKD.String(string(in)) {
+		switch {
+		case r < utf8.RuneSelf:
+			out.WriteRune(r)
+		case translits[r] != "":
+			out.WriteString(translits[r])
+		}
+	}
+	return out.String()
+}
+
+// confusables maps runes to the prototype runes they are visually
+// confused with. This is a subset of the Unicode Technical Standard #39
+// confusables data covering the Cyrillic, Greek, and symbol lookalikes
+// most commonly used to spoof Latin identifiers.
+var confusables = map[rune]string{
+	// Cyrillic
+	'а': "a", 'в': "B", 'е': "e", 'к': "K", 'м': "M", 'н': "H", 'о': "o",
+	'р': "p", 'с': "c", 'т': "T", 'у': "y", 'х': "x", 'ѕ': "s", 'і': "i",
+	'ј': "j", 'ԁ': "d", 'ԛ': "q", 'ԝ': "w", 'ӏ': "l", 'һ': "h", 'ь': "b",
+	'А': "A", 'В': "B", 'Е': "E", 'К': "K", 'М': "M", 'Н': "H", 'О': "O",
+	'Р': "P", 'С': "C", 'Т': "T", 'У': "Y", 'Х': "X", 'Ѕ': "S", 'І': "l",
+	'Ј': "J", 'Ԁ': "D", 'Ԛ': "Q", 'Ԝ': "W", 'Ӏ': "l",
+	// Greek
+	'α': "a", 'ο': "o", 'ν': "v", 'ρ': "p", 'τ': "t", 'ι': "i", 'κ': "k",
+	'υ': "u", 'χ': "x", 'γ': "y", 'Α': "A", 'Β': "B", 'Ε': "E", 'Ζ': "Z",
+	'Η': "H", 'Ι': "l", 'Κ': "K", 'Μ': "M", 'Ν': "N", 'Ο': "O", 'Ρ': "P",
+	'Τ': "T", 'Υ': "Y", 'Χ': "X",
+	// Latin and symbols
+	'I': "l", '1': "l", '|': "l", 'ǀ': "l", 'ℓ': "l", '0': "O",
+	'ɑ': "a", 'ɡ': "g", 'ı': "i", 'ȷ': "j", 'ʏ': "y", 'ꓲ': "l",
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '−': "-",
+	'‘': "'", '’': "'", '‛': "'", '′': "'", '`': "'",
+	'“': "''", '”': "''", '"': "''", '″': "''",
+	'\u200b': "", '\u200c': "", '\u200d': "", '\u2060': "", '\ufeff': "",
+}
+
+// Skeleton returns the confusable skeleton of the input as described in
+// Unicode Technical Standard #39 so that two strings that look alike
+// (paypal and pаypal with a Cyrillic а) have the same skeleton:
+//
+//	skeleton = NFD(confusables(NFD(in)))
+//
+// Skeletons are only for comparison and should never be displayed.
+// Note that only the most common lookalikes are mapped (see source).
+func Skeleton[T Text](in T) string {
+	var out strings.Builder
+	for _, r := range norm.NFD.String(string(in)) {
+		if s, has := confusables[r]; has {
+			out.WriteString(s)
+			continue
+		}
+		out.WriteRune(r)
+	}
+	return norm.NFD.String(out.String())
+}
+
+// Confusable returns true if the two have the same Skeleton.
+func Confusable[T Text](a, b T) bool { return Skeleton(a) == Skeleton(b) }
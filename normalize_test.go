@@ -0,0 +1,64 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleNFC() {
+	composed := "Caf\u00e9"
+	decomposed := "Cafe\u0301"
+	fmt.Println(composed == decomposed)
+	fmt.Println(to.NFC(composed) == to.NFC(decomposed))
+	fmt.Println(len(to.NFC(decomposed)), len(to.NFD(composed)))
+	// Output:
+	// false
+	// true
+	// 5 6
+}
+
+func ExampleNFKC() {
+	fmt.Println(to.NFKC("ﬁle Ｆｕｌｌ ①"))
+	fmt.Println(to.NFC("ﬁle Ｆｕｌｌ ①"))
+	fmt.Printf("%q\n", to.NFKD("é"))
+	// Output:
+	// file Full 1
+	// ﬁle Ｆｕｌｌ ①
+	// "é"
+}
+
+func ExampleASCIIFolded() {
+	fmt.Println(to.ASCIIFolded("Café"))
+	fmt.Println(to.ASCIIFolded("Straße"))
+	fmt.Println(to.ASCIIFolded([]rune("Ærøskøbing, Łódź")))
+	fmt.Println(to.ASCIIFolded("“ﬁne” — naïve…"))
+	fmt.Println(to.ASCIIFolded("日本 ok"))
+	// Output:
+	// Cafe
+	// Strasse
+	// AEroskobing, Lodz
+	// "fine" - naive...
+	//  ok
+}
+
+func ExampleSkeleton() {
+	latin := "paypal"
+	spoof := "pаypal" // Cyrillic а
+	fmt.Println(latin == spoof)
+	fmt.Println(to.Skeleton(latin) == to.Skeleton(spoof))
+	fmt.Println(to.Confusable("ADMIN", "АDMІN"))
+	fmt.Println(to.Confusable("l1I|", "llll"))
+	fmt.Println(to.Confusable("zero\u200bwidth", "zerowidth"))
+	fmt.Println(to.Confusable("rob", "bob"))
+	// Output:
+	// false
+	// true
+	// true
+	// true
+	// true
+	// false
+}
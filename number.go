@@ -0,0 +1,248 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat contains the separator between groups of three digits
+// and the decimal point for a given locale.
+type NumberFormat struct {
+	Sep   string
+	Point string
+}
+
+var (
+	EnglishNumbers = NumberFormat{Sep: ",", Point: "."}
+	GermanNumbers  = NumberFormat{Sep: ".", Point: ","}
+	FrenchNumbers  = NumberFormat{Sep: " ", Point: ","}
+	SwissNumbers   = NumberFormat{Sep: "'", Point: "."}
+)
+
+// DefaultNumberFormat is used by Grouped.
+var DefaultNumberFormat = EnglishNumbers
+
+// Grouped returns the integer with the digits grouped by thousands
+// using the DefaultNumberFormat (1,234,567).
+func Grouped(n int64) string { return DefaultNumberFormat.Int(n) }
+
+// Int returns the integer with the digits grouped by thousands.
+func (f NumberFormat) Int(n int64) string {
+	return f.group(strconv.FormatInt(n, 10))
+}
+
+// Float returns the number with the given number of digits after the
+// decimal point (see strconv.FormatFloat) and the integer digits grouped
+// by thousands. NaN and infinite values are returned as their strconv
+// form.
+func (f NumberFormat) Float(n float64, precision int) string {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(n, 'f', precision, 64)
+	whole, frac, has := strings.Cut(s, ".")
+	if !has {
+		return f.group(whole)
+	}
+	return f.group(whole) + f.Point + frac
+}
+
+func (f NumberFormat) group(digits string) string {
+	var sign string
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= 3 {
+		return sign + digits
+	}
+	var out strings.Builder
+	out.WriteString(sign)
+	lead := len(digits) % 3
+	if lead > 0 {
+		out.WriteString(digits[:lead])
+	}
+	for i := lead; i < len(digits); i += 3 {
+		if i > 0 {
+			out.WriteString(f.Sep)
+		}
+		out.WriteString(digits[i : i+3])
+	}
+	return out.String()
+}
+
+var siPrefixes = []string{"", "k", "M", "G", "T", "P", "E"}
+
+// Abbreviated returns the count abbreviated with an SI prefix (12.3k,
+// 4.5M) with no more than the given number of digits after the decimal
+// point. Trailing zeros (and the decimal point) are dropped. Numbers
+// less than 1000 (and NaN and infinite values) are not abbreviated.
+func Abbreviated(n float64, precision int) string {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	var sign string
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	var p int
+	for n >= 1000 && p < len(siPrefixes)-1 {
+		n /= 1000
+		p++
+	}
+	num := strconv.FormatFloat(n, 'f', precision, 64)
+	// rounding up can reach the next prefix (999.96k becomes 1000k)
+	if v, _ := strconv.ParseFloat(num, 64); v >= 1000 && p < len(siPrefixes)-1 {
+		p++
+		num = strconv.FormatFloat(n/1000, 'f', precision, 64)
+	}
+	if strings.Contains(num, ".") {
+		num = strings.TrimRight(strings.TrimRight(num, "0"), ".")
+	}
+	return sign + num + siPrefixes[p]
+}
+
+// Ordinal returns the integer with its English ordinal suffix (1st,
+// 2nd, 3rd, 11th, 22nd).
+func Ordinal(n int) string {
+	abs := n % 100
+	if abs < 0 {
+		abs = -abs
+	}
+	suffix := "th"
+	if abs < 11 || abs > 13 {
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+var smallNumbers = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven",
+	"eight", "nine", "ten", "eleven", "twelve", "thirteen", "fourteen",
+	"fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+}
+
+var tensNumbers = []string{
+	"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy",
+	"eighty", "ninety",
+}
+
+var scaleNumbers = []string{
+	"", "thousand", "million", "billion", "trillion", "quadrillion",
+	"quintillion",
+}
+
+// Spelled returns the integer spelled out in English words (one hundred
+// twenty-three) using the short scale (billion is 10^9). Negative
+// numbers begin with "minus".
+func Spelled(n int64) string {
+	if n == 0 {
+		return smallNumbers[0]
+	}
+	var words []string
+	u := uint64(n)
+	if n < 0 {
+		words = append(words, "minus")
+		u = uint64(-(n + 1)) + 1
+	}
+	var groups []uint64
+	for ; u > 0; u /= 1000 {
+		groups = append(groups, u%1000)
+	}
+	for g := len(groups) - 1; g >= 0; g-- {
+		if groups[g] == 0 {
+			continue
+		}
+		words = append(words, spelledHundreds(int(groups[g])))
+		if g > 0 {
+			words = append(words, scaleNumbers[g])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func spelledHundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, smallNumbers[n/100], "hundred")
+		n %= 100
+	}
+	switch {
+	case n == 0:
+	case n < 20:
+		words = append(words, smallNumbers[n])
+	case n%10 == 0:
+		words = append(words, tensNumbers[n/10])
+	default:
+		words = append(words, tensNumbers[n/10]+"-"+smallNumbers[n%10])
+	}
+	return strings.Join(words, " ")
+}
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"},
+	{90, "XC"}, {50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"},
+	{4, "IV"}, {1, "I"},
+}
+
+// Roman returns the integer as upper case Roman numerals or an error if
+// it is not between 1 and 3999.
+func Roman(n int) (string, error) {
+	if n < 1 || n > 3999 {
+		return "", fmt.Errorf("cannot convert %v to roman numerals (1-3999)", n)
+	}
+	var out strings.Builder
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			out.WriteString(r.symbol)
+			n -= r.value
+		}
+	}
+	return out.String(), nil
+}
+
+// ParseRoman returns the integer value of the Roman numerals (without
+// regard to case) or an error if they are not in standard form (IIII or
+// IC, for example).
+func ParseRoman(in string) (int, error) {
+	s := strings.ToUpper(strings.TrimSpace(in))
+	var n int
+	rest := s
+	for _, r := range romanNumerals {
+		for strings.HasPrefix(rest, r.symbol) {
+			n += r.value
+			rest = rest[len(r.symbol):]
+		}
+	}
+	if len(rest) > 0 || n == 0 {
+		return 0, fmt.Errorf("invalid roman numerals: %q", in)
+	}
+	if canon, _ := Roman(n); canon != s {
+		return 0, fmt.Errorf("invalid roman numerals: %q", in)
+	}
+	return n, nil
+}
+
+// Percent returns the ratio (0.123) as a percentage (12.3%) with
+// exactly the given number of digits after the decimal point. NaN and
+// infinite values are returned as their strconv form.
+func Percent(ratio float64, precision int) string {
+	if math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+		return strconv.FormatFloat(ratio, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(ratio*100, 'f', precision, 64) + "%"
+}
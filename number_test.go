@@ -0,0 +1,128 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleGrouped() {
+	fmt.Println(to.Grouped(0))
+	fmt.Println(to.Grouped(999))
+	fmt.Println(to.Grouped(1234))
+	fmt.Println(to.Grouped(-1234567))
+	fmt.Println(to.Grouped(math.MinInt64))
+	// Output:
+	// 0
+	// 999
+	// 1,234
+	// -1,234,567
+	// -9,223,372,036,854,775,808
+}
+
+func ExampleNumberFormat_Float() {
+	fmt.Println(to.EnglishNumbers.Float(1234567.891, 2))
+	fmt.Println(to.GermanNumbers.Float(1234567.891, 2))
+	fmt.Printf("%+q\n", to.FrenchNumbers.Float(1234.5, 1))
+	fmt.Println(to.SwissNumbers.Int(1000000))
+	fmt.Println(to.EnglishNumbers.Float(-999.5, 0))
+	fmt.Println(to.EnglishNumbers.Float(math.Inf(1), 2), to.EnglishNumbers.Float(math.NaN(), 2))
+	// Output:
+	// 1,234,567.89
+	// 1.234.567,89
+	// "1\u202f234,5"
+	// 1'000'000
+	// -1,000
+	// +Inf NaN
+}
+
+func ExampleAbbreviated() {
+	fmt.Println(to.Abbreviated(999, 1))
+	fmt.Println(to.Abbreviated(1000, 1))
+	fmt.Println(to.Abbreviated(12345, 1))
+	fmt.Println(to.Abbreviated(4500000, 1))
+	fmt.Println(to.Abbreviated(999960, 1))
+	fmt.Println(to.Abbreviated(-2500000000, 2))
+	fmt.Println(to.Abbreviated(math.Inf(-1), 1))
+	// Output:
+	// 999
+	// 1k
+	// 12.3k
+	// 4.5M
+	// 1M
+	// -2.5G
+	// -Inf
+}
+
+func ExampleOrdinal() {
+	for _, n := range []int{1, 2, 3, 4, 11, 12, 13, 21, 22, 101, 111, 112, -1, 0} {
+		fmt.Print(to.Ordinal(n), " ")
+	}
+	// Output:
+	// 1st 2nd 3rd 4th 11th 12th 13th 21st 22nd 101st 111th 112th -1st 0th
+}
+
+func ExampleSpelled() {
+	fmt.Println(to.Spelled(0))
+	fmt.Println(to.Spelled(13))
+	fmt.Println(to.Spelled(40))
+	fmt.Println(to.Spelled(123))
+	fmt.Println(to.Spelled(-1001))
+	fmt.Println(to.Spelled(2000000042))
+	fmt.Println(to.Spelled(math.MinInt64))
+	// Output:
+	// zero
+	// thirteen
+	// forty
+	// one hundred twenty-three
+	// minus one thousand one
+	// two billion forty-two
+	// minus nine quintillion two hundred twenty-three quadrillion three hundred seventy-two trillion thirty-six billion eight hundred fifty-four million seven hundred seventy-five thousand eight hundred eight
+}
+
+func ExampleRoman() {
+	for _, n := range []int{1, 4, 9, 14, 1994, 2022, 3999, 0, 4000} {
+		r, err := to.Roman(n)
+		fmt.Println(r, err)
+	}
+	// Output:
+	// I <nil>
+	// IV <nil>
+	// IX <nil>
+	// XIV <nil>
+	// MCMXCIV <nil>
+	// MMXXII <nil>
+	// MMMCMXCIX <nil>
+	//  cannot convert 0 to roman numerals (1-3999)
+	//  cannot convert 4000 to roman numerals (1-3999)
+}
+
+func ExampleParseRoman() {
+	for _, in := range []string{"XIV", "mcmxciv", "IIII", "IC", "ABC", ""} {
+		n, err := to.ParseRoman(in)
+		fmt.Println(n, err)
+	}
+	// Output:
+	// 14 <nil>
+	// 1994 <nil>
+	// 0 invalid roman numerals: "IIII"
+	// 0 invalid roman numerals: "IC"
+	// 0 invalid roman numerals: "ABC"
+	// 0 invalid roman numerals: ""
+}
+
+func ExamplePercent() {
+	fmt.Println(to.Percent(0.123, 1))
+	fmt.Println(to.Percent(0.5, 2))
+	fmt.Println(to.Percent(1, 0))
+	fmt.Println(to.Percent(math.NaN(), 1))
+	// Output:
+	// 12.3%
+	// 50.00%
+	// 100%
+	// NaN
+}
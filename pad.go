@@ -0,0 +1,120 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/width"
+)
+
+// RuneWidth returns the number of terminal columns the rune occupies:
+// two for East Asian wide and full-width runes (including most emoji),
+// zero for combining marks, format characters, and anything that is
+// not unicode.IsGraphic, and one for everything else.
+func RuneWidth(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case !unicode.IsGraphic(r):
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// DisplayWidth returns the number of terminal columns needed to display
+// the input. Like RuneCount, terminal escape sequences are ignored, but
+// every rune is measured with RuneWidth instead of counting as one.
+func DisplayWidth[T string | []byte | []rune](in T) int {
+	var c int
+	runes := []rune(string(in))
+	for n := 0; n < len(runes); n++ {
+		if runes[n] == '\033' && n+1 < len(runes) && runes[n+1] == '[' {
+			for n < len(runes) && runes[n] != 'm' {
+				n++
+			}
+			continue
+		}
+		c += RuneWidth(runes[n])
+	}
+	return c
+}
+
+// fill returns enough of the fill rune to fill the given number of
+// columns finishing with spaces if the fill rune is too wide to fit.
+func fill(cols int, with rune) string {
+	if cols <= 0 {
+		return ""
+	}
+	w := RuneWidth(with)
+	if w < 1 {
+		return strings.Repeat(" ", cols)
+	}
+	return strings.Repeat(string(with), cols/w) + strings.Repeat(" ", cols%w)
+}
+
+// PadRight returns the input followed by enough of the fill rune to
+// make it the given DisplayWidth. Input that is already as wide is
+// returned unchanged.
+func PadRight(in string, width int, with rune) string {
+	return in + fill(width-DisplayWidth(in), with)
+}
+
+// PadLeft returns the input preceded by enough of the fill rune to make
+// it the given DisplayWidth. Input that is already as wide is returned
+// unchanged.
+func PadLeft(in string, width int, with rune) string {
+	return fill(width-DisplayWidth(in), with) + in
+}
+
+// Center returns the input with enough of the fill rune on either side
+// to make it the given DisplayWidth. When the padding cannot be evenly
+// split the extra column goes on the right.
+func Center(in string, width int, with rune) string {
+	cols := width - DisplayWidth(in)
+	if cols <= 0 {
+		return in
+	}
+	return fill(cols/2, with) + in + fill(cols-cols/2, with)
+}
+
+// Columns aligns the tab-separated fields of every line (like
+// text/tabwriter) so that each column is as wide as its widest field
+// (see DisplayWidth) plus the gutter. Trailing white space is trimmed
+// from every line. Unlike text/tabwriter, colored and East Asian wide
+// text is aligned correctly.
+func Columns(in string, gutter int) string {
+	var rows [][]string
+	var widths []int
+	for _, line := range Lines(in) {
+		fields := strings.Split(line, "\t")
+		for n, field := range fields[:len(fields)-1] {
+			if n >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := DisplayWidth(field); w > widths[n] {
+				widths[n] = w
+			}
+		}
+		rows = append(rows, fields)
+	}
+	lines := make([]string, len(rows))
+	for r, fields := range rows {
+		var line strings.Builder
+		for n, field := range fields {
+			if n == len(fields)-1 {
+				line.WriteString(field)
+				break
+			}
+			line.WriteString(PadRight(field, widths[n]+gutter, ' '))
+		}
+		lines[r] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
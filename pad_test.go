@@ -0,0 +1,70 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleDisplayWidth() {
+	fmt.Println(to.DisplayWidth("some"))
+	fmt.Println(to.DisplayWidth("\033[32msome\033[0m"))
+	fmt.Println(to.DisplayWidth("日本語"))
+	fmt.Println(to.DisplayWidth("💚ok"))
+	fmt.Println(to.DisplayWidth("Café"))
+	// Output:
+	// 4
+	// 4
+	// 6
+	// 4
+	// 4
+}
+
+func ExamplePadRight() {
+	fmt.Printf("%q\n", to.PadRight("some", 8, ' '))
+	fmt.Printf("%q\n", to.PadRight("\033[31mred\033[0m", 6, '.'))
+	fmt.Printf("%q\n", to.PadRight("日本", 7, ' '))
+	fmt.Printf("%q\n", to.PadRight("too wide", 4, ' '))
+	fmt.Printf("%q\n", to.PadRight("x", 6, '＊'))
+	// Output:
+	// "some    "
+	// "\x1b[31mred\x1b[0m..."
+	// "日本   "
+	// "too wide"
+	// "x＊＊ "
+}
+
+func ExamplePadLeft() {
+	fmt.Printf("%q\n", to.PadLeft("42", 6, '0'))
+	fmt.Printf("%q\n", to.PadLeft("日本", 6, ' '))
+	// Output:
+	// "000042"
+	// "  日本"
+}
+
+func ExampleCenter() {
+	fmt.Printf("%q\n", to.Center("title", 11, '-'))
+	fmt.Printf("%q\n", to.Center("日本", 7, ' '))
+	// Output:
+	// "---title---"
+	// " 日本  "
+}
+
+func ExampleColumns() {
+	in := "NAME\tLANG\tDESC\nrwxrob\tGo\tsome thing\n日本語\tBash\tother"
+	fmt.Println(to.Columns(in, 2))
+	// Output:
+	// NAME    LANG  DESC
+	// rwxrob  Go    some thing
+	// 日本語  Bash  other
+}
+
+func ExampleColumns_escapes() {
+	in := "\033[31mred\033[0m\tone\nplain\ttwo"
+	fmt.Printf("%q\n", to.Columns(in, 1))
+	// Output:
+	// "\x1b[31mred\x1b[0m   one\nplain two"
+}
@@ -0,0 +1,302 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// PlainTextConv contains the configuration used to Convert HTML into
+// readable plain text wrapped to Width (see Wrapped).
+type PlainTextConv struct {
+	Width int
+}
+
+// PlainText is shorthand for PlainTextConv{Width: width}.Convert.
+func PlainText(in any, width int) string {
+	return PlainTextConv{Width: width}.Convert(in)
+}
+
+// htmlBlocks are the elements that begin a new block of text. Those
+// mapped to false are dropped entirely.
+var htmlBlocks = map[atom.Atom]bool{
+	atom.Html: true, atom.Body: true, atom.P: true, atom.Div: true,
+	atom.Section: true, atom.Article: true, atom.Main: true,
+	atom.Header: true, atom.Footer: true, atom.Nav: true, atom.Aside: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.H5: true, atom.H6: true, atom.Ul: true, atom.Ol: true,
+	atom.Li: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Hr: true, atom.Table: true,
+	atom.Form: true, atom.Fieldset: true, atom.Figure: true,
+	atom.Figcaption: true, atom.Address: true, atom.Details: true,
+	atom.Summary: true, atom.Head: false, atom.Script: false,
+	atom.Style: false, atom.Template: false, atom.Noscript: false,
+	atom.Title: false,
+}
+
+// plainText holds the state of a single conversion.
+type plainText struct {
+	links []string
+}
+
+// Convert returns the HTML (anything String accepts) as plain text:
+//
+//   - head, script, style, template, and noscript are dropped
+//   - paragraphs and other blocks are wrapped and separated by a blank
+//     line (br elements are kept as line breaks)
+//   - h1 and h2 are underlined with = and - (others are as is)
+//   - ul items begin with a bullet (•) and ol items with a number
+//   - dd and blockquote are indented (the latter with >)
+//   - pre is kept verbatim (never wrapped)
+//   - tables are drawn as an ASCIITable (th in the first row become the
+//     headers)
+//   - images are replaced by their alt text
+//   - links are followed by a footnote reference ([1]) and all the
+//     footnotes (URLs) are listed at the end
+//
+// Entities are always decoded. The result ends with a line return unless
+// empty.
+func (c PlainTextConv) Convert(in any) string {
+	doc, err := html.Parse(strings.NewReader(String(in)))
+	if err != nil {
+		return ""
+	}
+	var p plainText
+	out := strings.Join(p.blocks(doc, c.Width), "\n\n")
+	if len(p.links) > 0 {
+		refs := make([]string, len(p.links))
+		for n, link := range p.links {
+			refs[n] = "[" + strconv.Itoa(n+1) + "] " + link
+		}
+		out += "\n\n" + strings.Join(refs, "\n")
+	}
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+func htmlAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// blocks returns the children of n rendered as blocks of text with runs
+// of inline content wrapped to width.
+func (p *plainText) blocks(n *html.Node, width int) []string {
+	var blocks []string
+	var inline strings.Builder
+	flush := func() {
+		var lines []string
+		for _, seg := range strings.Split(inline.String(), "\n") {
+			if seg = strings.TrimSpace(seg); seg != "" {
+				seg, _ = Wrapped(seg, width)
+				lines = append(lines, seg)
+			}
+		}
+		if len(lines) > 0 {
+			blocks = append(blocks, strings.Join(lines, "\n"))
+		}
+		inline.Reset()
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if _, is := htmlBlocks[c.DataAtom]; is && c.Type == html.ElementNode {
+			flush()
+			if b := p.block(c, width); b != "" {
+				blocks = append(blocks, b)
+			}
+			continue
+		}
+		p.inline(&inline, c)
+	}
+	flush()
+	return blocks
+}
+
+// inline writes the text of n with all white space as single spaces
+// (other than br which becomes a line return).
+func (p *plainText) inline(out *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		out.WriteString(strings.Map(func(r rune) rune {
+			switch r {
+			case '\n', '\r', '\t', '\f':
+				return ' '
+			}
+			return r
+		}, n.Data))
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	switch n.DataAtom {
+	case atom.Br:
+		out.WriteString("\n")
+		return
+	case atom.Img:
+		out.WriteString(htmlAttr(n, "alt"))
+		return
+	}
+	if keep, is := htmlBlocks[n.DataAtom]; is && !keep {
+		return
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		p.inline(out, c)
+	}
+	if n.DataAtom == atom.A {
+		if ref := p.footnote(htmlAttr(n, "href")); ref != "" {
+			out.WriteString(ref)
+		}
+	}
+}
+
+// footnote returns the reference to the link (adding it if new) or an
+// empty string for links within the same page or to scripts.
+func (p *plainText) footnote(link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" || strings.HasPrefix(link, "#") ||
+		strings.HasPrefix(strings.ToLower(link), "javascript:") {
+		return ""
+	}
+	n := len(p.links)
+	for i, l := range p.links {
+		if l == link {
+			n = i
+			break
+		}
+	}
+	if n == len(p.links) {
+		p.links = append(p.links, link)
+	}
+	return "[" + strconv.Itoa(n+1) + "]"
+}
+
+// text returns the inline text of n with white space crunched.
+func (p *plainText) text(n *html.Node) string {
+	var out strings.Builder
+	p.inline(&out, n)
+	return Words(out.String())
+}
+
+// verbatim returns all the text within n as is.
+func verbatim(n *html.Node) string {
+	var out strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			out.WriteString(n.Data)
+		case n.DataAtom == atom.Br:
+			out.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return out.String()
+}
+
+func (p *plainText) block(n *html.Node, width int) string {
+	if !htmlBlocks[n.DataAtom] {
+		return ""
+	}
+	switch n.DataAtom {
+
+	case atom.H1, atom.H2:
+		text := strings.Join(p.blocks(n, width), "\n")
+		var w int
+		for _, line := range Lines(text) {
+			if c := DisplayWidth(line); c > w {
+				w = c
+			}
+		}
+		under := "="
+		if n.DataAtom == atom.H2 {
+			under = "-"
+		}
+		return text + "\n" + strings.Repeat(under, w)
+
+	case atom.Ul, atom.Ol:
+		var items []*html.Node
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.DataAtom == atom.Li {
+				items = append(items, c)
+			}
+		}
+		start := 1
+		if s, err := strconv.Atoi(htmlAttr(n, "start")); err == nil {
+			start = s
+		}
+		mw := 2
+		if n.DataAtom == atom.Ol {
+			mw = len(strconv.Itoa(start+len(items)-1)) + 2
+		}
+		lines := make([]string, 0, len(items))
+		for i, item := range items {
+			marker := "• "
+			if n.DataAtom == atom.Ol {
+				marker = PadLeft(strconv.Itoa(start+i)+".", mw-1, ' ') + " "
+			}
+			body := strings.Join(p.blocks(item, width-mw), "\n")
+			lines = append(lines, mdPrefixed(body, marker, strings.Repeat(" ", mw)))
+		}
+		return strings.Join(lines, "\n")
+
+	case atom.Dd:
+		return mdPrefixed(strings.Join(p.blocks(n, width-4), "\n\n"), "    ", "    ")
+
+	case atom.Blockquote:
+		return mdPrefixed(strings.Join(p.blocks(n, width-2), "\n\n"), "> ", "> ")
+
+	case atom.Pre:
+		return strings.TrimRight(verbatim(n), "\n")
+
+	case atom.Hr:
+		if width < 1 {
+			return "---"
+		}
+		return strings.Repeat("-", width)
+
+	case atom.Table:
+		var rows [][]string
+		var headers []string
+		var walk func(n *html.Node)
+		walk = func(n *html.Node) {
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if c.DataAtom != atom.Tr {
+					walk(c)
+					continue
+				}
+				var row []string
+				allth := true
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.DataAtom == atom.Td || cell.DataAtom == atom.Th {
+						row = append(row, p.text(cell))
+						allth = allth && cell.DataAtom == atom.Th
+					}
+				}
+				if allth && rows == nil && headers == nil {
+					headers = row
+					continue
+				}
+				rows = append(rows, row)
+			}
+		}
+		walk(n)
+		out, _ := Table{Style: ASCIITable, Headers: headers}.Render(rows)
+		return out
+	}
+
+	return strings.Join(p.blocks(n, width), "\n\n")
+}
@@ -0,0 +1,74 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rwxrob/to"
+)
+
+func ExamplePlainText() {
+	in := `<html><head><title>Docs</title><style>p{}</style></head>
+<body>
+<h1>Getting &amp; Started</h1>
+<p>Read the <a href="https://rwx.gg">full
+   guide</a> or <a href="#top">jump up</a>. See <a href="https://rwx.gg">it</a>
+   again<br>and <img alt="[logo]" src="x.png"> here.</p>
+<script>alert("nope")</script>
+<h2>Steps</h2>
+<ol><li>one</li><li>two<ul><li>sub</li></ul></li></ol>
+<blockquote>quoted &lt;text&gt;</blockquote>
+<pre>
+  x := 1
+  fmt.Println(x)
+</pre>
+<table>
+<tr><th>Name</th><th>Qty</th></tr>
+<tr><td>apple</td><td>3</td></tr>
+</table>
+</body></html>`
+	fmt.Print(to.PlainText(strings.NewReader(in), 30))
+	// Output:
+	// Getting & Started
+	// =================
+	//
+	// Read the full guide[1] or jump
+	// up. See it[1] again
+	// and [logo] here.
+	//
+	// Steps
+	// -----
+	//
+	// 1. one
+	// 2. two
+	//    • sub
+	//
+	// > quoted <text>
+	//
+	//   x := 1
+	//   fmt.Println(x)
+	//
+	// +-------+-----+
+	// | Name  | Qty |
+	// +-------+-----+
+	// | apple | 3   |
+	// +-------+-----+
+	//
+	// [1] https://rwx.gg
+}
+
+func ExamplePlainTextConv_Convert() {
+	in := []byte("<dl><dt>term</dt><dd>the definition</dd></dl><hr><p>a   b&nbsp;c</p>")
+	fmt.Print(to.PlainTextConv{}.Convert(in))
+	// Output:
+	// term
+	//
+	//     the definition
+	//
+	// ---
+	//
+	// a b c
+}
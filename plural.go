@@ -0,0 +1,176 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Uncountable contains the lower case English words that are the same
+// whether singular or plural. Add to it as needed.
+var Uncountable = map[string]bool{
+	"advice": true, "aircraft": true, "bison": true, "cattle": true,
+	"deer": true, "equipment": true, "evidence": true, "feedback": true,
+	"fish": true, "furniture": true, "information": true, "jeans": true,
+	"knowledge": true, "luggage": true, "metadata": true, "moose": true,
+	"money": true, "news": true, "police": true, "rice": true,
+	"salmon": true, "series": true, "sheep": true, "software": true,
+	"species": true, "staff": true, "swine": true, "traffic": true,
+}
+
+// Irregulars maps the lower case singular form of an irregular English
+// noun to its plural. Add to it as needed (Singular uses it in
+// reverse).
+var Irregulars = map[string]string{
+	"alumnus": "alumni", "analysis": "analyses", "appendix": "appendices",
+	"axis": "axes", "basis": "bases", "cactus": "cacti", "calf": "calves",
+	"child": "children", "crisis": "crises", "criterion": "criteria",
+	"datum": "data", "diagnosis": "diagnoses", "echo": "echoes", "elf": "elves",
+	"foot": "feet", "goose": "geese", "half": "halves", "hero": "heroes",
+	"index": "indices", "knife": "knives", "leaf": "leaves",
+	"life": "lives", "loaf": "loaves", "man": "men", "matrix": "matrices",
+	"medium": "media", "mouse": "mice", "ox": "oxen",
+	"person": "people", "phenomenon": "phenomena", "potato": "potatoes",
+	"quiz": "quizzes", "self": "selves", "shelf": "shelves",
+	"thesis": "theses", "thief": "thieves",
+	"tomato": "tomatoes", "tooth": "teeth", "vertex": "vertices",
+	"veto": "vetoes", "wife": "wives", "wolf": "wolves", "woman": "women",
+}
+
+// ieNouns are the lower case nouns ending in ie (movie/movies) and
+// sNouns those ending in s (gas/gases) that Singular cannot otherwise
+// tell apart from the plurals of words ending in y (city/cities) or
+// e (case/cases).
+var (
+	ieNouns = map[string]bool{
+		"brownie": true, "calorie": true, "cookie": true, "genie": true,
+		"goalie": true, "hippie": true, "movie": true, "pixie": true,
+		"prairie": true, "rookie": true, "selfie": true, "zombie": true,
+	}
+	sNouns = map[string]bool{
+		"alias": true, "atlas": true, "bonus": true, "bus": true,
+		"campus": true, "canvas": true, "census": true, "gas": true,
+		"iris": true, "lens": true, "plus": true, "status": true,
+		"virus": true, "walrus": true,
+	}
+)
+
+// cased returns the word in the same case as the original: all upper,
+// capitalized, or as is.
+func cased(orig, word string) string {
+	if orig == strings.ToUpper(orig) && orig != strings.ToLower(orig) {
+		return strings.ToUpper(word)
+	}
+	runes := []rune(orig)
+	if len(runes) > 0 && unicode.IsUpper(runes[0]) {
+		w := []rune(word)
+		w[0] = unicode.ToUpper(w[0])
+		return string(w)
+	}
+	return word
+}
+
+func isvowel(b byte) bool { return strings.IndexByte("aeiou", b) >= 0 }
+
+// Plural returns the plural form of the (singular) English word
+// honoring Uncountable and Irregulars and the common suffix rules
+// (box/boxes, city/cities, day/days). Case is preserved (File/Files,
+// FILE/FILES). Only the last word of multiple words is changed.
+func Plural(word string) string {
+	if i := strings.LastIndexByte(word, ' '); i >= 0 {
+		return word[:i+1] + Plural(word[i+1:])
+	}
+	w := strings.ToLower(word)
+	switch {
+	case w == "" || Uncountable[w]:
+		return word
+	case Irregulars[w] != "":
+		return cased(word, Irregulars[w])
+	case strings.HasSuffix(w, "y") && len(w) > 1 && !isvowel(w[len(w)-2]):
+		return cased(word, w[:len(w)-1]+"ies")
+	case strings.HasSuffix(w, "sis"):
+		return cased(word, w[:len(w)-2]+"es")
+	case strings.HasSuffix(w, "s"), strings.HasSuffix(w, "x"),
+		strings.HasSuffix(w, "z"), strings.HasSuffix(w, "ch"),
+		strings.HasSuffix(w, "sh"):
+		return cased(word, w+"es")
+	}
+	return cased(word, w+"s")
+}
+
+// Singular returns the singular form of the (plural) English word. It
+// is the reverse of Plural. Words ending in ies become y (cities/city)
+// unless that would leave a single letter (ties/tie) or the word is
+// a known noun ending in ie (movies/movie).
+func Singular(word string) string {
+	if i := strings.LastIndexByte(word, ' '); i >= 0 {
+		return word[:i+1] + Singular(word[i+1:])
+	}
+	w := strings.ToLower(word)
+	if w == "" || Uncountable[w] {
+		return word
+	}
+	for s, p := range Irregulars {
+		if p == w {
+			return cased(word, s)
+		}
+	}
+	if _, is := Irregulars[w]; is {
+		return word
+	}
+	switch {
+	case strings.HasSuffix(w, "es") && sNouns[w[:len(w)-2]]:
+		return cased(word, w[:len(w)-2])
+	case strings.HasSuffix(w, "ies") && (len(w) < 5 || ieNouns[w[:len(w)-1]]):
+		return cased(word, w[:len(w)-1])
+	case strings.HasSuffix(w, "ies"):
+		return cased(word, w[:len(w)-3]+"y")
+	case strings.HasSuffix(w, "sses"), strings.HasSuffix(w, "xes"),
+		strings.HasSuffix(w, "zes"), strings.HasSuffix(w, "ches"),
+		strings.HasSuffix(w, "shes"):
+		return cased(word, w[:len(w)-2])
+	case strings.HasSuffix(w, "ss"), strings.HasSuffix(w, "us"),
+		strings.HasSuffix(w, "is"):
+		return word
+	case strings.HasSuffix(w, "s"):
+		return cased(word, w[:len(w)-1])
+	}
+	return word
+}
+
+// Quantity returns the count followed by the word made Plural if the
+// count is anything but one (1 file, 3 files). A zero count is "no"
+// instead (no files).
+func Quantity(n int, word string) string {
+	switch n {
+	case 0:
+		return "no " + Plural(word)
+	case 1:
+		return "1 " + word
+	}
+	return strconv.Itoa(n) + " " + Plural(word)
+}
+
+// Listed returns the items joined into an English list with commas and
+// the conjunction (usually "and" or "or") before the last item: "a and
+// b", "a, b, and c". The comma before the conjunction (Oxford comma) is
+// only added if oxford is true. See Human for a bracketed alternative.
+func Listed(items []string, conj string, oxford bool) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " " + conj + " " + items[1]
+	}
+	last := len(items) - 1
+	list := strings.Join(items[:last], ", ")
+	if oxford {
+		list += ","
+	}
+	return list + " " + conj + " " + items[last]
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExamplePlural() {
+	for _, w := range []string{
+		"file", "box", "church", "city", "day", "bus", "analysis",
+		"child", "Person", "sheep", "FILE", "leaf", "config file", "",
+		"movie", "gas",
+	} {
+		fmt.Printf("%q ", to.Plural(w))
+	}
+	// Output:
+	// "files" "boxes" "churches" "cities" "days" "buses" "analyses" "children" "People" "sheep" "FILES" "leaves" "config files" "" "movies" "gases"
+}
+
+func ExampleSingular() {
+	for _, w := range []string{
+		"files", "boxes", "churches", "cities", "days", "buses", "analyses",
+		"children", "People", "sheep", "FILES", "leaves", "classes",
+		"status", "movies", "config files", "zombies", "Ties", "pies",
+		"gases", "cases", "statuses",
+	} {
+		fmt.Printf("%q ", to.Singular(w))
+	}
+	// Output:
+	// "file" "box" "church" "city" "day" "bus" "analysis" "child" "Person" "sheep" "FILE" "leaf" "class" "status" "movie" "config file" "zombie" "Tie" "pie" "gas" "case" "status"
+}
+
+func ExampleQuantity() {
+	fmt.Println(to.Quantity(0, "file"), "changed")
+	fmt.Println(to.Quantity(1, "file"), "changed")
+	fmt.Println(to.Quantity(3, "file"), "changed")
+	fmt.Println(to.Quantity(2, "child"))
+	// Output:
+	// no files changed
+	// 1 file changed
+	// 3 files changed
+	// 2 children
+}
+
+func ExampleListed() {
+	fmt.Printf("%q\n", to.Listed(nil, "and", true))
+	fmt.Println(to.Listed([]string{"a"}, "and", true))
+	fmt.Println(to.Listed([]string{"a", "b"}, "and", true))
+	fmt.Println(to.Listed([]string{"a", "b", "c"}, "and", true))
+	fmt.Println(to.Listed([]string{"a", "b", "c"}, "or", false))
+	// Output:
+	// ""
+	// a
+	// a and b
+	// a, b, and c
+	// a, b or c
+}
@@ -0,0 +1,252 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ManPage contains the structured help used to Render a roff man(7)
+// page. Description and the Body of every additional Section are
+// Markdown (see Roff) as is the Desc of every Option. Section defaults
+// to 1.
+type ManPage struct {
+	Name        string
+	Summary     string
+	Section     string
+	Date        string
+	Source      string
+	Manual      string
+	Synopsis    []string
+	Description string
+	Options     []ManOption
+	Sections    []ManSection
+}
+
+// ManOption is a single option (Flags such as "-a, --all") and its
+// description.
+type ManOption struct {
+	Flags string
+	Desc  string
+}
+
+// ManSection is an additional titled section of a ManPage.
+type ManSection struct {
+	Title string
+	Body  string
+}
+
+// RoffEscaped returns the text escaped for roff so that it is printed
+// as is: backslashes become \e, hyphens become \- (minus signs, which
+// is what options need to be copied and pasted), and any line beginning
+// with a period or apostrophe (which roff would take as a request) is
+// preceded by \&.
+func RoffEscaped(in string) string {
+	return roffLines(roffText(in))
+}
+
+// roffText escapes backslashes and hyphens only.
+func roffText(in string) string {
+	in = strings.ReplaceAll(in, `\`, `\e`)
+	return strings.ReplaceAll(in, "-", `\-`)
+}
+
+// roffLines protects every line beginning with a period or apostrophe.
+func roffLines(in string) string {
+	lines := strings.Split(in, "\n")
+	for n, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[n] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// roffQuoted returns the argument of a request in double quotes.
+func roffQuoted(in string) string {
+	return `"` + strings.ReplaceAll(roffText(in), `"`, `\(dq`) + `"`
+}
+
+func roffStyle(span mdSpan, text, url string) string {
+	switch span {
+	case mdStrong, mdCodeSpan:
+		return `\fB` + text + `\fP`
+	case mdEmph:
+		return `\fI` + text + `\fP`
+	case mdLink, mdImage:
+		if url != "" && text != roffText(url) {
+			return text + " (" + roffText(url) + ")"
+		}
+	}
+	return text
+}
+
+func roffInline(in string) string { return mdInline(in, roffStyle, roffText) }
+
+// Roff returns the Markdown (see MarkdownConv for the subset supported)
+// as the body of a roff man(7) page. Level 1 headings become sections
+// (.SH) in upper case and all others become subsections (.SS).
+// Paragraphs are never wrapped (roff fills them) but hard line breaks
+// are kept (.br). Lists use .IP, block quotes .RS, and code blocks and
+// tables (see Table) are kept as is with no-fill (.nf). All text is
+// escaped (see RoffEscaped).
+func Roff(markdown string) string {
+	markdown = strings.ReplaceAll(markdown, "\t", "    ")
+	out := roffBlocks(mdParse(Lines(markdown)), 1)
+	if out == "" {
+		return ""
+	}
+	return out + "\n"
+}
+
+// roffBlocks renders the blocks with headings below level top as .SS.
+func roffBlocks(blocks []mdBlock, top int) string {
+	parts := make([]string, len(blocks))
+	for n, b := range blocks {
+		parts[n] = roffBlock(b, top)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// roffPara returns the lines of the paragraph joined with hard breaks
+// kept.
+func roffPara(lines []string) string {
+	var segs, cur []string
+	for n, line := range lines {
+		hard := n < len(lines)-1 &&
+			(strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`))
+		if hard {
+			line = strings.TrimSuffix(strings.TrimRight(line, " "), `\`)
+		}
+		cur = append(cur, strings.TrimSpace(line))
+		if hard {
+			segs = append(segs, roffLines(roffInline(strings.Join(cur, " "))))
+			cur = nil
+		}
+	}
+	segs = append(segs, roffLines(roffInline(strings.Join(cur, " "))))
+	return strings.Join(segs, "\n.br\n")
+}
+
+func roffBlock(b mdBlock, top int) string {
+	switch b.kind {
+
+	case mdHeading:
+		text := roffInline(strings.Join(b.lines, " "))
+		if b.level <= top {
+			return ".SH " + roffQuotedText(strings.ToUpper(text))
+		}
+		return ".SS " + roffQuotedText(text)
+
+	case mdCode:
+		return ".PP\n.RS 4\n.nf\n" + RoffEscaped(strings.Join(b.lines, "\n")) + "\n.fi\n.RE"
+
+	case mdRule:
+		return ".PP\n\\l'\\n(.lu'"
+
+	case mdQuote:
+		return ".RS 4\n" + roffBlocks(b.children, top) + "\n.RE"
+
+	case mdList:
+		mw := 2
+		if b.ordered {
+			mw = len(strconv.Itoa(b.start+len(b.items)-1)) + 2
+		}
+		items := make([]string, len(b.items))
+		for n, item := range b.items {
+			tag := `\(bu`
+			if b.ordered {
+				tag = strconv.Itoa(b.start+n) + "."
+			}
+			parts := []string{".IP " + tag + " " + strconv.Itoa(mw)}
+			for i, blk := range item {
+				if i == 0 && blk.kind == mdPara {
+					parts = append(parts, roffPara(blk.lines))
+					continue
+				}
+				parts = append(parts, ".RS\n"+roffBlock(blk, top)+"\n.RE")
+			}
+			items[n] = strings.Join(parts, "\n")
+		}
+		return strings.Join(items, "\n")
+
+	case mdTable:
+		t := Table{Style: ASCIITable, Align: b.align, Headers: b.rows[0]}
+		out, _ := t.Render(b.rows[1:])
+		return ".PP\n.nf\n" + RoffEscaped(out) + "\n.fi"
+	}
+
+	return ".PP\n" + roffPara(b.lines)
+}
+
+// roffQuotedText quotes already escaped text as a request argument.
+func roffQuotedText(in string) string {
+	return `"` + strings.ReplaceAll(in, `"`, `\(dq`) + `"`
+}
+
+// Render returns the page as roff man(7) with the following sections
+// (those that are empty are omitted): NAME, SYNOPSIS, DESCRIPTION,
+// OPTIONS, and any additional Sections in order. Every Synopsis line is
+// kept on its own line with the Name (when it begins the line) in
+// bold. Level 1 headings within Markdown become subsections (.SS).
+func (m ManPage) Render() string {
+	sec := m.Section
+	if sec == "" {
+		sec = "1"
+	}
+	var out strings.Builder
+	out.WriteString(".TH " + roffQuoted(strings.ToUpper(m.Name)) + " " + roffQuoted(sec))
+	for _, f := range []string{m.Date, m.Source, m.Manual} {
+		out.WriteString(" " + roffQuoted(f))
+	}
+	out.WriteString("\n.SH NAME\n" + roffLines(roffText(m.Name)))
+	if m.Summary != "" {
+		out.WriteString(` \- ` + roffText(m.Summary))
+	}
+	out.WriteString("\n")
+
+	if len(m.Synopsis) > 0 {
+		out.WriteString(".SH SYNOPSIS\n.nf\n")
+		for _, line := range m.Synopsis {
+			if m.Name != "" && strings.HasPrefix(line, m.Name) {
+				line = `\fB` + roffText(m.Name) + `\fP` + roffText(line[len(m.Name):])
+			} else {
+				line = roffLines(roffText(line))
+			}
+			out.WriteString(line + "\n")
+		}
+		out.WriteString(".fi\n")
+	}
+
+	body := func(title, markdown string) {
+		blocks := mdParse(Lines(strings.ReplaceAll(markdown, "\t", "    ")))
+		if len(blocks) == 0 {
+			return
+		}
+		out.WriteString(".SH " + roffQuoted(strings.ToUpper(title)) + "\n")
+		out.WriteString(roffBlocks(blocks, 0) + "\n")
+	}
+	body("Description", m.Description)
+
+	if len(m.Options) > 0 {
+		out.WriteString(".SH OPTIONS\n")
+		for _, o := range m.Options {
+			out.WriteString(".TP\n" + `\fB` + roffText(o.Flags) + `\fP` + "\n")
+			desc := mdParse(Lines(o.Desc))
+			for n, b := range desc {
+				if n == 0 && b.kind == mdPara {
+					out.WriteString(roffPara(b.lines) + "\n")
+					continue
+				}
+				out.WriteString(".RS\n" + roffBlock(b, 0) + "\n.RE\n")
+			}
+		}
+	}
+
+	for _, s := range m.Sections {
+		body(s.Title, s.Body)
+	}
+	return out.String()
+}
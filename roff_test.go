@@ -0,0 +1,90 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleRoffEscaped() {
+	fmt.Println(to.RoffEscaped(`use --all or \n`))
+	fmt.Println(to.RoffEscaped(".start and\n'quote"))
+	// Output:
+	// use \-\-all or \en
+	// \&.start and
+	// \&'quote
+}
+
+func ExampleRoff() {
+	in := "# Usage\n\nRun **foo** with `--all` to see *everything*\nor [docs](https://rwx.gg).  \n.dot first\n\n- one\n- two\n\n```\ncode \\n here\n```\n"
+	fmt.Print(to.Roff(in))
+	// Output:
+	// .SH "USAGE"
+	// .PP
+	// Run \fBfoo\fP with \fB\-\-all\fP to see \fIeverything\fP or docs (https://rwx.gg).
+	// .br
+	// \&.dot first
+	// .IP \(bu 2
+	// one
+	// .IP \(bu 2
+	// two
+	// .PP
+	// .RS 4
+	// .nf
+	// code \en here
+	// .fi
+	// .RE
+}
+
+func ExampleManPage_Render() {
+	page := to.ManPage{
+		Name:     "foo",
+		Summary:  "do foo-like things",
+		Date:     "2022-10-01",
+		Source:   "foo 1.0",
+		Manual:   "User Commands",
+		Synopsis: []string{"foo [-a] FILE...", "foo --version"},
+		Description: `The **foo** command does things.
+
+## Details
+
+1. first
+2. second`,
+		Options: []to.ManOption{
+			{Flags: "-a, --all", Desc: "Show *all* things."},
+			{Flags: "--version", Desc: "Print the version."},
+		},
+		Sections: []to.ManSection{{Title: "See Also", Body: "bar(1)"}},
+	}
+	fmt.Print(page.Render())
+	// Output:
+	// .TH "FOO" "1" "2022\-10\-01" "foo 1.0" "User Commands"
+	// .SH NAME
+	// foo \- do foo\-like things
+	// .SH SYNOPSIS
+	// .nf
+	// \fBfoo\fP [\-a] FILE...
+	// \fBfoo\fP \-\-version
+	// .fi
+	// .SH "DESCRIPTION"
+	// .PP
+	// The \fBfoo\fP command does things.
+	// .SS "Details"
+	// .IP 1. 3
+	// first
+	// .IP 2. 3
+	// second
+	// .SH OPTIONS
+	// .TP
+	// \fB\-a, \-\-all\fP
+	// Show \fIall\fP things.
+	// .TP
+	// \fB\-\-version\fP
+	// Print the version.
+	// .SH "SEE ALSO"
+	// .PP
+	// bar(1)
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"fmt"
+	"strings"
+)
+
+// shellsafe contains all the runes that never need quoting when passed
+// as a single word to a POSIX shell.
+const shellsafe = `abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789@%_-+=:,./`
+
+// ShellQuoted returns the string quoted for safe use as a single word in
+// any POSIX shell. Strings containing only safe characters are returned
+// unchanged. Everything else is wrapped in single quotes with every
+// embedded single quote replaced by a closing quote, an escaped quote,
+// and an opening quote. The empty string becomes a pair of single
+// quotes.
+func ShellQuoted(in string) string {
+	if len(in) == 0 {
+		return `''`
+	}
+	if strings.Trim(in, shellsafe) == "" {
+		return in
+	}
+	return `'` + strings.ReplaceAll(in, `'`, `'\''`) + `'`
+}
+
+// CommandLine returns the arguments joined with a single space after
+// quoting each with ShellQuoted producing something that can be safely
+// copied and pasted into any POSIX shell. See Args for the reverse.
+func CommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for n, arg := range args {
+		quoted[n] = ShellQuoted(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Args splits a command line string into its arguments (argv) the same
+// way a POSIX shell would without doing any expansion or substitution
+// of any kind:
+//
+//   - unquoted white space separates arguments
+//   - single quotes preserve everything until the next single quote
+//   - double quotes preserve everything except backslash escapes
+//     of $, `, ", \, and newline
+//   - backslash outside of quotes escapes the next rune
+//   - backslash followed by newline continues the line
+//   - # at the beginning of an argument begins a comment until the
+//     end of the line
+//
+// Returns an error (with the byte offset of the problem) if a quote is
+// never terminated or the input ends with an escaping backslash.
+func Args(in string) ([]string, error) {
+	args := []string{}
+	var cur strings.Builder
+	var inword bool
+	runes := []rune(in)
+	offset := func(n int) int { return len(string(runes[:n])) }
+
+	for n := 0; n < len(runes); n++ {
+		r := runes[n]
+		switch {
+
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inword {
+				args = append(args, cur.String())
+				cur.Reset()
+				inword = false
+			}
+
+		case r == '#' && !inword:
+			for n < len(runes) && runes[n] != '\n' {
+				n++
+			}
+
+		case r == '\\':
+			if n+1 >= len(runes) {
+				return args, fmt.Errorf("trailing backslash at offset %v", offset(n))
+			}
+			n++
+			if runes[n] == '\n' {
+				continue
+			}
+			cur.WriteRune(runes[n])
+			inword = true
+
+		case r == '\'':
+			start := n
+			n++
+			for n < len(runes) && runes[n] != '\'' {
+				cur.WriteRune(runes[n])
+				n++
+			}
+			if n >= len(runes) {
+				return args, fmt.Errorf("unterminated single quote at offset %v", offset(start))
+			}
+			inword = true
+
+		case r == '"':
+			start := n
+			n++
+			for ; n < len(runes) && runes[n] != '"'; n++ {
+				if runes[n] == '\\' && n+1 < len(runes) {
+					switch runes[n+1] {
+					case '$', '`', '"', '\\':
+						n++
+					case '\n':
+						n++
+						continue
+					}
+				}
+				cur.WriteRune(runes[n])
+			}
+			if n >= len(runes) {
+				return args, fmt.Errorf("unterminated double quote at offset %v", offset(start))
+			}
+			inword = true
+
+		default:
+			cur.WriteRune(r)
+			inword = true
+		}
+	}
+
+	if inword {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
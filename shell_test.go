@@ -0,0 +1,69 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleShellQuoted() {
+	fmt.Println(to.ShellQuoted(`simple`))
+	fmt.Println(to.ShellQuoted(`/usr/local/bin`))
+	fmt.Println(to.ShellQuoted(`with space`))
+	fmt.Println(to.ShellQuoted(`it's`))
+	fmt.Println(to.ShellQuoted(`$HOME`))
+	fmt.Println(to.ShellQuoted(``))
+	// Output:
+	// simple
+	// /usr/local/bin
+	// 'with space'
+	// 'it'\''s'
+	// '$HOME'
+	// ''
+}
+
+func ExampleCommandLine() {
+	fmt.Println(to.CommandLine([]string{"echo", "hello world", "it's", ""}))
+	// Output:
+	// echo 'hello world' 'it'\''s' ''
+}
+
+func ExampleArgs() {
+	args, err := to.Args(`echo 'single  quoted' "double \"quoted\"" back\ slash '' # comment`)
+	fmt.Println(err)
+	fmt.Printf("%q\n", args)
+	// Output:
+	// <nil>
+	// ["echo" "single  quoted" "double \"quoted\"" "back slash" ""]
+}
+
+func ExampleArgs_continued() {
+	args, _ := to.Args("one \\\ntwo \"thr\\\nee\" # ignored\nfour")
+	fmt.Printf("%q\n", args)
+	// Output:
+	// ["one" "two" "three" "four"]
+}
+
+func ExampleArgs_roundTrip() {
+	in := []string{"ls", "-l", "my file", `it's "here"`}
+	args, _ := to.Args(to.CommandLine(in))
+	fmt.Printf("%q\n", args)
+	// Output:
+	// ["ls" "-l" "my file" "it's \"here\""]
+}
+
+func ExampleArgs_unterminated() {
+	_, err := to.Args(`echo 'oops`)
+	fmt.Println(err)
+	_, err = to.Args(`echo "oops`)
+	fmt.Println(err)
+	_, err = to.Args(`echo oops\`)
+	fmt.Println(err)
+	// Output:
+	// unterminated single quote at offset 5
+	// unterminated double quote at offset 5
+	// trailing backslash at offset 9
+}
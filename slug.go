@@ -0,0 +1,96 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Slug returns a URL slug from the input by folding it to ASCII (see
+// ASCIIFolded), converting it to lower case, and joining every run of
+// letters and digits with the separator. If max is greater than zero
+// the slug is cut at the last word boundary that fits within max bytes
+// (or within the first word if it is longer than max by itself).
+func Slug(in string, sep string, max int) string {
+	words := strings.FieldsFunc(
+		strings.ToLower(ASCIIFolded(in)),
+		func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) },
+	)
+	var slug string
+	for _, word := range words {
+		next := word
+		if len(slug) > 0 {
+			next = slug + sep + word
+		}
+		if max > 0 && len(next) > max {
+			if len(slug) == 0 {
+				slug = word[:max]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+// MaxFilename is the maximum length in bytes of a SafeFilename.
+var MaxFilename = 255
+
+// reservedFilenames are those that cannot be used on Windows regardless
+// of case or extension.
+var reservedFilenames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// SafeFilename returns a file name that is safe to use on any common
+// operating system:
+//
+//   - control and other non-printable runes are dropped (see Visible)
+//   - reserved runes (< > : " / \ | ? *) are replaced with repl
+//   - leading and trailing white space is trimmed
+//   - trailing dots and spaces are trimmed
+//   - reserved names (CON, NUL, COM1, etc.) have an underscore added
+//   - length is cut to MaxFilename bytes (without splitting runes)
+//
+// Pass an empty repl to strip the reserved runes instead. An underscore
+// is returned if nothing remains so the result is never empty, ".", or
+// "..".
+func SafeFilename(in string, repl string) string {
+	var out strings.Builder
+	for _, r := range Visible(in) {
+		if strings.ContainsRune(`<>:"/\|?*`, r) {
+			out.WriteString(repl)
+			continue
+		}
+		out.WriteRune(r)
+	}
+	name := strings.TrimRight(strings.TrimSpace(out.String()), ". ")
+
+	base := name
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		base = name[:i]
+	}
+	if reservedFilenames[strings.ToUpper(strings.TrimRight(base, " "))] {
+		name = base + "_" + name[len(base):]
+	}
+
+	if len(name) > MaxFilename {
+		cut := MaxFilename
+		for cut > 0 && !utf8.RuneStart(name[cut]) {
+			cut--
+		}
+		name = strings.TrimRight(name[:cut], ". ")
+	}
+
+	if name == "" {
+		return "_"
+	}
+	return name
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleSlug() {
+	fmt.Println(to.Slug(`Café con Leche: A Straße Story!`, "-", 0))
+	fmt.Println(to.Slug(`  Hello,   World  `, "_", 0))
+	fmt.Println(to.Slug(`Some really long title here`, "-", 16))
+	fmt.Println(to.Slug(`Supercalifragilistic`, "-", 5))
+	fmt.Println(to.Slug(`Ærøskøbing Łódź`, "-", 0))
+	// Output:
+	// cafe-con-leche-a-strasse-story
+	// hello_world
+	// some-really-long
+	// super
+	// aeroskobing-lodz
+}
+
+func ExampleSafeFilename() {
+	fmt.Println(to.SafeFilename(`report: 2022/10/18?.txt`, "_"))
+	fmt.Println(to.SafeFilename(`report: 2022/10/18?.txt`, ""))
+	fmt.Println(to.SafeFilename("bell\a and tab\t", "_"))
+	fmt.Println(to.SafeFilename(`trailing dots... `, "_"))
+	fmt.Println(to.SafeFilename(`con.txt`, "_"))
+	fmt.Println(to.SafeFilename(`NUL`, "_"))
+	fmt.Println(to.SafeFilename(`..`, "_"))
+	fmt.Println(to.SafeFilename(`???`, ""))
+	fmt.Println(len(to.SafeFilename(strings.Repeat("ü", 200), "_")))
+	// Output:
+	// report_ 2022_10_18_.txt
+	// report 20221018.txt
+	// bell and tab
+	// trailing dots
+	// con_.txt
+	// NUL_
+	// _
+	// _
+	// 254
+}
@@ -0,0 +1,558 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StructMapper contains the configuration used to Map a struct and
+// set a Struct from a map. Tag is the struct field tag used for field
+// names and options (default "json"). Only the omitempty option is
+// used. Fields tagged with "-" are skipped.
+type StructMapper struct {
+	Tag string
+}
+
+// Map is shorthand for StructMapper{}.Map.
+func Map(in any) (map[string]any, error) { return StructMapper{}.Map(in) }
+
+// Struct is shorthand for StructMapper{}.Struct.
+func Struct(m map[string]any, dst any) error { return StructMapper{}.Struct(m, dst) }
+
+func (c StructMapper) tag() string {
+	if c.Tag == "" {
+		return "json"
+	}
+	return c.Tag
+}
+
+// FieldError is a problem with a single field. Path is the dotted path
+// to the field (spec.ports.0.name).
+type FieldError struct {
+	Path string
+	Msg  string
+}
+
+func (e FieldError) Error() string { return e.Path + ": " + e.Msg }
+
+// FieldErrors contains every FieldError encountered.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for n, err := range e {
+		msgs[n] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// structField is an exported struct field with its tag options.
+type structField struct {
+	name      string
+	index     []int
+	omitempty bool
+	tagged    bool
+}
+
+// structFields returns the fields of the struct type with the fields
+// of any untagged embedded structs promoted following the same rules as
+// encoding/json: of the fields with the same name the shallowest wins
+// (then the only one tagged) and any still ambiguous are dropped.
+func (c StructMapper) structFields(t reflect.Type) []structField {
+	var all []structField
+	c.collect(t, nil, map[reflect.Type]bool{}, &all)
+	byname := map[string][]structField{}
+	var names []string
+	for _, f := range all {
+		if byname[f.name] == nil {
+			names = append(names, f.name)
+		}
+		byname[f.name] = append(byname[f.name], f)
+	}
+	var fields []structField
+	for _, name := range names {
+		if f, ok := dominant(byname[name]); ok {
+			fields = append(fields, f)
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for n := 0; n < len(a) && n < len(b); n++ {
+			if a[n] != b[n] {
+				return a[n] < b[n]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return fields
+}
+
+// collect adds every field of t (at index within the outermost struct)
+// to all, descending into embedded structs not already being visited.
+func (c StructMapper) collect(t reflect.Type, index []int, visiting map[reflect.Type]bool, all *[]structField) {
+	if visiting[t] {
+		return
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+	for n := 0; n < t.NumField(); n++ {
+		f := t.Field(n)
+		tag := f.Tag.Get(c.tag())
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		idx := append(append([]int{}, index...), n)
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			c.collect(ft, idx, visiting, all)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = f.Name
+		}
+		*all = append(*all, structField{
+			name:      name,
+			index:     idx,
+			omitempty: strings.Contains(","+opts+",", ",omitempty,"),
+			tagged:    tagged,
+		})
+	}
+}
+
+// dominant returns the field that wins among those with the same name
+// (see structFields) or false if none does.
+func dominant(fields []structField) (structField, bool) {
+	depth := len(fields[0].index)
+	for _, f := range fields[1:] {
+		if len(f.index) < depth {
+			depth = len(f.index)
+		}
+	}
+	var shallow []structField
+	for _, f := range fields {
+		if len(f.index) == depth {
+			shallow = append(shallow, f)
+		}
+	}
+	if len(shallow) == 1 {
+		return shallow[0], true
+	}
+	var tagged []structField
+	for _, f := range shallow {
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return structField{}, false
+}
+
+// Map returns the struct (or pointer to one) as a map with a key for
+// every exported field named by Tag (or the field name). Untagged
+// embedded structs have their fields promoted (with the same rules for
+// conflicting names as encoding/json). Nested structs (including those
+// in slices) are also converted to maps unless they implement
+// encoding.TextMarshaler (time.Time, for example). Empty values of
+// omitempty fields are omitted. Returns an error if not passed
+// a struct. See Struct for the reverse.
+func (c StructMapper) Map(in any) (map[string]any, error) {
+	v := reflect.ValueOf(in)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot convert %T to map", in)
+	}
+	return c.structMap(v), nil
+}
+
+func (c StructMapper) structMap(v reflect.Value) map[string]any {
+	m := map[string]any{}
+	for _, f := range c.structFields(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index, false)
+		if !ok {
+			continue
+		}
+		if f.omitempty && isEmpty(fv) {
+			continue
+		}
+		m[f.name] = c.mapValue(fv)
+	}
+	return m
+}
+
+// mapValue returns the value as it would appear in a Map.
+func (c StructMapper) mapValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Pointer && v.Elem().Kind() == reflect.Struct &&
+			!v.Type().Implements(textMarshaler) {
+			return c.structMap(v.Elem())
+		}
+	case reflect.Struct:
+		if !v.Type().Implements(textMarshaler) {
+			return c.structMap(v)
+		}
+	case reflect.Slice, reflect.Array:
+		et := v.Type().Elem()
+		for et.Kind() == reflect.Pointer {
+			et = et.Elem()
+		}
+		if et.Kind() == reflect.Struct && !et.Implements(textMarshaler) {
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return nil
+			}
+			list := make([]any, v.Len())
+			for n := range list {
+				list[n] = c.mapValue(v.Index(n))
+			}
+			return list
+		}
+	}
+	return v.Interface()
+}
+
+// isEmpty is the same definition of empty as encoding/json omitempty.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
+
+// fieldByIndex is reflect.Value.FieldByIndex but returns false (and the
+// nil pointer) for nil embedded pointers instead of panicking (or
+// allocates them if alloc and they can be set, which those to
+// unexported structs cannot).
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for n, i := range index {
+		if n > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !alloc || !v.CanSet() {
+					return v, false
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// Struct sets the fields of the struct pointed to by dst from the map
+// (as created by Map) matching keys to Tag names (or field names)
+// exactly or, failing that, without regard to case. Values are coerced
+// into the type of the field where possible:
+//
+//   - strings from any scalar (see String)
+//   - numbers from any number or string that fits without overflow
+//     (floats only into integers if they have no fraction)
+//   - bools from bools or strings (see strconv.ParseBool)
+//   - encoding.TextUnmarshaler (time.Time) from strings
+//   - nested structs from maps with string keys
+//   - slices and maps from slices and maps (element by element)
+//   - pointers are allocated as needed
+//
+// Every unknown key, value that cannot be coerced, or field promoted
+// from a nil embedded pointer to an unexported struct (which cannot be
+// allocated) is reported in the FieldErrors returned with its dotted
+// path (spec.ports.0.name). All other fields are still set.
+func (c StructMapper) Struct(m map[string]any, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot set %T (must be pointer to struct)", dst)
+	}
+	var errs FieldErrors
+	c.setStruct(v.Elem(), reflect.ValueOf(m), "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func (c StructMapper) setStruct(v, m reflect.Value, path string, errs *FieldErrors) {
+	fields := c.structFields(v.Type())
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	for _, k := range keys {
+		key := k.String()
+		var field *structField
+		for n := range fields {
+			if fields[n].name == key {
+				field = &fields[n]
+				break
+			}
+		}
+		if field == nil {
+			for n := range fields {
+				if strings.EqualFold(fields[n].name, key) {
+					field = &fields[n]
+					break
+				}
+			}
+		}
+		p := joinPath(path, key)
+		if field == nil {
+			*errs = append(*errs, FieldError{p, "unknown field"})
+			continue
+		}
+		fv, ok := fieldByIndex(v, field.index, true)
+		if !ok {
+			*errs = append(*errs, FieldError{p,
+				fmt.Sprintf("cannot set embedded pointer to unexported struct %v", fv.Type().Elem())})
+			continue
+		}
+		c.setValue(fv, m.MapIndex(k), p, errs)
+	}
+}
+
+func (c StructMapper) setValue(v, in reflect.Value, path string, errs *FieldErrors) {
+	for in.Kind() == reflect.Interface {
+		in = in.Elem()
+	}
+	if !in.IsValid() {
+		v.Set(reflect.Zero(v.Type()))
+		return
+	}
+	mistyped := func() {
+		*errs = append(*errs, FieldError{path,
+			fmt.Sprintf("cannot use %v (%v) as %v", Human(in.Interface()), in.Type(), v.Type())})
+	}
+
+	if v.Kind() != reflect.Interface && in.Type().AssignableTo(v.Type()) {
+		v.Set(in)
+		return
+	}
+
+	if in.Kind() == reflect.String && reflect.PointerTo(v.Type()).Implements(textUnmarshaler) {
+		u := v.Addr().Interface().(encoding.TextUnmarshaler)
+		if err := u.UnmarshalText([]byte(in.String())); err != nil {
+			*errs = append(*errs, FieldError{path, err.Error()})
+		}
+		return
+	}
+
+	switch v.Kind() {
+
+	case reflect.Interface:
+		if !in.Type().AssignableTo(v.Type()) {
+			mistyped()
+			return
+		}
+		v.Set(in)
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		c.setValue(v.Elem(), in, path, errs)
+
+	case reflect.String:
+		if !isScalar(in) {
+			mistyped()
+			return
+		}
+		v.SetString(String(in.Interface()))
+
+	case reflect.Bool:
+		switch in.Kind() {
+		case reflect.Bool:
+			v.SetBool(in.Bool())
+		case reflect.String:
+			b, err := strconv.ParseBool(in.String())
+			if err != nil {
+				mistyped()
+				return
+			}
+			v.SetBool(b)
+		default:
+			mistyped()
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch in.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i = in.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if in.Uint() > math.MaxInt64 {
+				mistyped()
+				return
+			}
+			i = int64(in.Uint())
+		case reflect.Float32, reflect.Float64:
+			f := in.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				mistyped()
+				return
+			}
+			i = int64(f)
+		case reflect.String:
+			var err error
+			if i, err = strconv.ParseInt(strings.TrimSpace(in.String()), 0, 64); err != nil {
+				mistyped()
+				return
+			}
+		default:
+			mistyped()
+			return
+		}
+		if v.OverflowInt(i) {
+			mistyped()
+			return
+		}
+		v.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch in.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if in.Int() < 0 {
+				mistyped()
+				return
+			}
+			u = uint64(in.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u = in.Uint()
+		case reflect.Float32, reflect.Float64:
+			f := in.Float()
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+				mistyped()
+				return
+			}
+			u = uint64(f)
+		case reflect.String:
+			var err error
+			if u, err = strconv.ParseUint(strings.TrimSpace(in.String()), 0, 64); err != nil {
+				mistyped()
+				return
+			}
+		default:
+			mistyped()
+			return
+		}
+		if v.OverflowUint(u) {
+			mistyped()
+			return
+		}
+		v.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch in.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(in.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			f = float64(in.Uint())
+		case reflect.Float32, reflect.Float64:
+			f = in.Float()
+		case reflect.String:
+			var err error
+			if f, err = strconv.ParseFloat(strings.TrimSpace(in.String()), 64); err != nil {
+				mistyped()
+				return
+			}
+		default:
+			mistyped()
+			return
+		}
+		if v.OverflowFloat(f) {
+			mistyped()
+			return
+		}
+		v.SetFloat(f)
+
+	case reflect.Struct:
+		if in.Kind() != reflect.Map || in.Type().Key().Kind() != reflect.String {
+			mistyped()
+			return
+		}
+		c.setStruct(v, in, path, errs)
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && in.Kind() == reflect.String {
+			v.SetBytes(Bytes(in.String()))
+			return
+		}
+		if in.Kind() != reflect.Slice && in.Kind() != reflect.Array {
+			mistyped()
+			return
+		}
+		s := reflect.MakeSlice(v.Type(), in.Len(), in.Len())
+		for n := 0; n < in.Len(); n++ {
+			c.setValue(s.Index(n), in.Index(n), joinPath(path, strconv.Itoa(n)), errs)
+		}
+		v.Set(s)
+
+	case reflect.Array:
+		if (in.Kind() != reflect.Slice && in.Kind() != reflect.Array) || in.Len() > v.Len() {
+			mistyped()
+			return
+		}
+		for n := 0; n < in.Len(); n++ {
+			c.setValue(v.Index(n), in.Index(n), joinPath(path, strconv.Itoa(n)), errs)
+		}
+
+	case reflect.Map:
+		if in.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String ||
+			in.Type().Key().Kind() != reflect.String {
+			mistyped()
+			return
+		}
+		mv := reflect.MakeMapWithSize(v.Type(), in.Len())
+		for _, k := range in.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			c.setValue(elem, in.MapIndex(k), joinPath(path, k.String()), errs)
+			mv.SetMapIndex(reflect.ValueOf(k.String()).Convert(v.Type().Key()), elem)
+		}
+		v.Set(mv)
+
+	default:
+		mistyped()
+	}
+}
+
+// isScalar returns true for the kinds that have a sensible String form.
+func isScalar(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct,
+		reflect.Func, reflect.Chan, reflect.Pointer, reflect.UnsafePointer:
+		return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rwxrob/to"
+)
+
+type Meta struct {
+	Created time.Time `json:"created"`
+	Owner   string    `json:"owner,omitempty"`
+}
+
+type Port struct {
+	Name string `json:"name"`
+	Num  uint16 `json:"num"`
+}
+
+type Service struct {
+	Meta
+	Name    string            `json:"name"`
+	Replica int               `json:"replicas"`
+	Debug   bool              `json:"debug,omitempty"`
+	Ports   []Port            `json:"ports"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Secret  string            `json:"-"`
+	Ratio   *float64
+	private int
+}
+
+func ExampleMap() {
+	created, _ := time.Parse(time.RFC3339, "2022-10-18T00:00:00Z")
+	svc := Service{
+		Meta:    Meta{Created: created},
+		Name:    "web",
+		Replica: 3,
+		Ports:   []Port{{"http", 80}},
+		Secret:  "shh",
+	}
+	m, err := to.Map(&svc)
+	fmt.Println(err)
+	fmt.Println(m)
+	_, err = to.Map(42)
+	fmt.Println(err)
+	// Output:
+	// <nil>
+	// map[Ratio:<nil> created:2022-10-18 00:00:00 +0000 UTC name:web ports:[map[name:http num:80]] replicas:3]
+	// cannot convert int to map
+}
+
+func ExampleStruct() {
+	var svc Service
+	err := to.Struct(map[string]any{
+		"created":  "2022-10-18T00:00:00Z",
+		"name":     "web",
+		"replicas": "3",
+		"debug":    "true",
+		"ports":    []any{map[string]any{"name": "http", "num": 80.0}},
+		"labels":   map[string]any{"tier": "front"},
+		"RATIO":    0.5,
+	}, &svc)
+	fmt.Println(err)
+	fmt.Println(svc.Created.Year(), svc.Name, svc.Replica, svc.Debug)
+	fmt.Println(svc.Ports, svc.Labels, *svc.Ratio)
+	// Output:
+	// <nil>
+	// 2022 web 3 true
+	// [{http 80}] map[tier:front] 0.5
+}
+
+func ExampleStruct_roundTrip() {
+	ratio := 0.25
+	in := Service{Name: "db", Replica: 1, Ports: []Port{{"pg", 5432}}, Ratio: &ratio}
+	m, _ := to.Map(in)
+	var out Service
+	fmt.Println(to.Struct(m, &out))
+	fmt.Println(out.Name, out.Replica, out.Ports, *out.Ratio)
+	// Output:
+	// <nil>
+	// db 1 [{pg 5432}] 0.25
+}
+
+func ExampleStruct_errors() {
+	var svc Service
+	err := to.Struct(map[string]any{
+		"name":     []string{"not", "scalar"},
+		"replicas": 1.5,
+		"bogus":    true,
+		"ports":    []any{map[string]any{"name": "http", "num": 70000, "extra": 1}},
+		"created":  "yesterday",
+	}, &svc)
+	for _, e := range err.(to.FieldErrors) {
+		fmt.Println(e)
+	}
+	fmt.Println(to.Struct(nil, svc))
+	// Output:
+	// bogus: unknown field
+	// created: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"
+	// name: cannot use ["not","scalar"] ([]string) as string
+	// ports.0.extra: unknown field
+	// ports.0.num: cannot use 70000 (int) as uint16
+	// replicas: cannot use 1.5 (float64) as int
+	// cannot set to_test.Service (must be pointer to struct)
+}
+
+type base struct {
+	ID   int
+	Name string
+}
+
+type Named struct {
+	Name string `json:"name"`
+}
+
+type Labeled struct {
+	Label string
+}
+
+type Titled struct {
+	Label string
+}
+
+type Record struct {
+	base
+	Named
+	Labeled
+	Titled
+	Name string
+}
+
+func ExampleMap_promoted() {
+	r := Record{
+		base:    base{ID: 1, Name: "base"},
+		Named:   Named{Name: "named"},
+		Labeled: Labeled{Label: "labeled"},
+		Titled:  Titled{Label: "titled"},
+		Name:    "outer",
+	}
+	m, _ := to.Map(r)
+	fmt.Println(m)
+	// Output:
+	// map[ID:1 Name:outer name:named]
+}
+
+type Hidden struct {
+	*base
+	Note string
+}
+
+func ExampleStruct_unexportedEmbedded() {
+	var h Hidden
+	err := to.Struct(map[string]any{"ID": 1, "Note": "set"}, &h)
+	fmt.Println(err)
+	fmt.Println(h.base == nil, h.Note)
+	h.base = &base{}
+	fmt.Println(to.Struct(map[string]any{"ID": 1}, &h), h.ID)
+	// Output:
+	// ID: cannot set embedded pointer to unexported struct to_test.base
+	// true set
+	// <nil> 1
+}
+
+func ExampleStructMapper() {
+	type Config struct {
+		Host string `yaml:"host" json:"hostname"`
+		Port int    `yaml:"port,omitempty"`
+	}
+	m, _ := to.StructMapper{Tag: "yaml"}.Map(Config{Host: "localhost"})
+	fmt.Println(m)
+	var c Config
+	fmt.Println(to.StructMapper{Tag: "yaml"}.Struct(map[string]any{"host": "example.com", "port": "80"}, &c))
+	fmt.Println(c)
+	// Output:
+	// map[host:localhost]
+	// <nil>
+	// {example.com 80}
+}
@@ -0,0 +1,401 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Align is used to align text within a given width.
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// TableStyle contains the runes (as strings) used to draw a Table. Each
+// of Top, Sep (under the headers), and Bottom contain the left edge,
+// horizontal line, junction, and right edge. Leave any line empty to
+// omit it entirely. Row contains the left edge, the column separator,
+// and the right edge. Pad is the number of spaces on each side of every
+// cell. Markdown adds alignment colons to Sep, escapes any pipes within
+// cells, joins the lines of every cell with <br>, and adds an empty
+// header row if there are no Headers (since a Markdown table must have
+// one).
+type TableStyle struct {
+	Top      [4]string
+	Sep      [4]string
+	Bottom   [4]string
+	Row      [3]string
+	Pad      int
+	Markdown bool
+}
+
+var (
+	BoxTable = TableStyle{
+		Top:    [4]string{"┌", "─", "┬", "┐"},
+		Sep:    [4]string{"├", "─", "┼", "┤"},
+		Bottom: [4]string{"└", "─", "┴", "┘"},
+		Row:    [3]string{"│", "│", "│"},
+		Pad:    1,
+	}
+
+	ASCIITable = TableStyle{
+		Top:    [4]string{"+", "-", "+", "+"},
+		Sep:    [4]string{"+", "-", "+", "+"},
+		Bottom: [4]string{"+", "-", "+", "+"},
+		Row:    [3]string{"|", "|", "|"},
+		Pad:    1,
+	}
+
+	MarkdownTable = TableStyle{
+		Sep:      [4]string{"|", "-", "|", "|"},
+		Row:      [3]string{"|", "|", "|"},
+		Pad:      1,
+		Markdown: true,
+	}
+
+	PlainTable = TableStyle{
+		Row: [3]string{"", "   ", ""},
+	}
+)
+
+// Table contains the configuration used to Render tabular data as
+// aligned text. Headers are the column titles (see Render). Align and
+// MaxWidth are per column (missing values default to AlignLeft and no
+// maximum). Cells wider than their MaxWidth are wrapped between words
+// unless Truncate is true in which case they are cut to fit (see
+// Truncated). All widths are calculated with DisplayWidth so that
+// cells with terminal escapes (color) and wide runes still line up.
+type Table struct {
+	Style    TableStyle
+	Headers  []string
+	Align    []Align
+	MaxWidth []int
+	Truncate bool
+}
+
+// Tabled is shorthand for rendering the data with a BoxTable and no
+// other configuration (see Table.Render).
+func Tabled(data any) (string, error) {
+	return Table{Style: BoxTable}.Render(data)
+}
+
+// Render returns the data as an aligned text table or an error if the
+// data is not one of the following (or pointers to them):
+//
+//   - [][]string or any slice of slices (Headers are added if set)
+//   - []map[string]any or any slice of string keyed maps (Headers, if
+//     set, select and order the keys, otherwise all keys are sorted)
+//   - slice of structs (exported fields in order unless tagged with
+//     `table:"-"`, titled by Headers, `table:"Title"`, or field name)
+//
+// Every cell is converted with String. Nil cells are empty.
+func (t Table) Render(data any) (string, error) {
+	headers, rows, err := t.cells(data)
+	if err != nil {
+		return "", err
+	}
+
+	var ncols int
+	for _, row := range append([][]string{headers}, rows...) {
+		if len(row) > ncols {
+			ncols = len(row)
+		}
+	}
+	if ncols == 0 {
+		return "", nil
+	}
+	if t.Style.Markdown && headers == nil {
+		headers = make([]string, ncols)
+	}
+
+	// split every cell into the lines it will occupy
+	var lines [][][]string
+	if headers != nil {
+		lines = append(lines, t.lines(headers, ncols))
+	}
+	for _, row := range rows {
+		lines = append(lines, t.lines(row, ncols))
+	}
+
+	widths := make([]int, ncols)
+	for _, row := range lines {
+		for c, cell := range row {
+			for _, line := range cell {
+				if n := DisplayWidth(line); n > widths[c] {
+					widths[c] = n
+				}
+			}
+		}
+	}
+
+	st := t.Style
+	var out strings.Builder
+	t.rule(&out, st.Top, widths)
+	for n, row := range lines {
+		t.row(&out, row, widths)
+		if n == 0 && headers != nil {
+			t.rule(&out, st.Sep, widths)
+		}
+	}
+	t.rule(&out, st.Bottom, widths)
+	return strings.TrimSuffix(out.String(), "\n"), nil
+}
+
+func (t Table) align(col int) Align {
+	if col < len(t.Align) {
+		return t.Align[col]
+	}
+	return AlignLeft
+}
+
+// lines returns the cells of the row each split into the lines needed
+// to fit within any MaxWidth.
+func (t Table) lines(row []string, ncols int) [][]string {
+	cells := make([][]string, ncols)
+	for c := 0; c < ncols; c++ {
+		var cell string
+		if c < len(row) {
+			cell = row[c]
+		}
+		if t.Style.Markdown {
+			cell = strings.ReplaceAll(cell, "|", `\|`)
+		}
+		max := 0
+		if c < len(t.MaxWidth) {
+			max = t.MaxWidth[c]
+		}
+		for _, line := range Lines(cell) {
+			if max < 1 || DisplayWidth(line) <= max {
+				cells[c] = append(cells[c], line)
+				continue
+			}
+			if !t.Truncate {
+				for _, w := range displayWrapped(line, max) {
+					cells[c] = append(cells[c], Truncated(w, max))
+				}
+				continue
+			}
+			cells[c] = append(cells[c], Truncated(line, max))
+		}
+		if t.Style.Markdown && len(cells[c]) > 1 {
+			cells[c] = []string{strings.Join(cells[c], "<br>")}
+		}
+	}
+	return cells
+}
+
+// displayWrapped is Wrapped but measured with DisplayWidth and returns
+// the lines. Words that are too wide are left on a line by themselves.
+func displayWrapped(in string, width int) []string {
+	var lines []string
+	var line string
+	var cur int
+	for _, word := range strings.Fields(in) {
+		w := DisplayWidth(word)
+		switch {
+		case line == "":
+			line, cur = word, w
+		case cur+1+w > width:
+			lines = append(lines, line)
+			line, cur = word, w
+		default:
+			line += " " + word
+			cur += 1 + w
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (t Table) rule(out *strings.Builder, edges [4]string, widths []int) {
+	if edges == [4]string{} {
+		return
+	}
+	out.WriteString(edges[0])
+	for c, w := range widths {
+		if c > 0 {
+			out.WriteString(edges[2])
+		}
+		seg := strings.Repeat(edges[1], w+2*t.Style.Pad)
+		if t.Style.Markdown && len(seg) > 1 {
+			switch t.align(c) {
+			case AlignRight:
+				seg = seg[:len(seg)-1] + ":"
+			case AlignCenter:
+				seg = ":" + seg[1:len(seg)-1] + ":"
+			}
+		}
+		out.WriteString(seg)
+	}
+	out.WriteString(edges[3] + "\n")
+}
+
+func (t Table) row(out *strings.Builder, cells [][]string, widths []int) {
+	var height int
+	for _, cell := range cells {
+		if len(cell) > height {
+			height = len(cell)
+		}
+	}
+	if height == 0 {
+		height = 1
+	}
+	pad := strings.Repeat(" ", t.Style.Pad)
+	for l := 0; l < height; l++ {
+		var line strings.Builder
+		line.WriteString(t.Style.Row[0])
+		for c, cell := range cells {
+			if c > 0 {
+				line.WriteString(t.Style.Row[1])
+			}
+			var text string
+			if l < len(cell) {
+				text = cell[l]
+			}
+			line.WriteString(pad + aligned(text, widths[c], t.align(c)) + pad)
+		}
+		line.WriteString(t.Style.Row[2])
+		str := line.String()
+		if t.Style.Row[2] == "" {
+			str = strings.TrimRight(str, " ")
+		}
+		out.WriteString(str + "\n")
+	}
+}
+
+// aligned pads the string with spaces to the width (see DisplayWidth).
+func aligned(in string, width int, align Align) string {
+	switch align {
+	case AlignRight:
+		return PadLeft(in, width, ' ')
+	case AlignCenter:
+		return Center(in, width, ' ')
+	default:
+		return PadRight(in, width, ' ')
+	}
+}
+
+// cells converts the data into string headers and rows.
+func (t Table) cells(data any) ([]string, [][]string, error) {
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, nil, fmt.Errorf("cannot render %T as table", data)
+	}
+
+	cell := func(v reflect.Value) string {
+		for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return ""
+			}
+			if s, is := v.Interface().(fmt.Stringer); is {
+				return s.String()
+			}
+			v = v.Elem()
+		}
+		if !v.IsValid() {
+			return ""
+		}
+		return String(v.Interface())
+	}
+
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+	rows := [][]string{}
+
+	switch {
+
+	case elem.Kind() == reflect.Slice || elem.Kind() == reflect.Array ||
+		elem.Kind() == reflect.Interface:
+		for n := 0; n < v.Len(); n++ {
+			r := reflect.Indirect(v.Index(n))
+			if r.Kind() == reflect.Interface {
+				r = reflect.Indirect(r.Elem())
+			}
+			if r.Kind() != reflect.Slice && r.Kind() != reflect.Array {
+				return nil, nil, fmt.Errorf("cannot render row %v (%v) as table row", n, r.Kind())
+			}
+			row := make([]string, r.Len())
+			for c := range row {
+				row[c] = cell(r.Index(c))
+			}
+			rows = append(rows, row)
+		}
+		return t.Headers, rows, nil
+
+	case elem.Kind() == reflect.Map && elem.Key().Kind() == reflect.String:
+		headers := t.Headers
+		if headers == nil {
+			seen := map[string]bool{}
+			for n := 0; n < v.Len(); n++ {
+				for _, k := range reflect.Indirect(v.Index(n)).MapKeys() {
+					if !seen[k.String()] {
+						seen[k.String()] = true
+						headers = append(headers, k.String())
+					}
+				}
+			}
+			sort.Strings(headers)
+		}
+		for n := 0; n < v.Len(); n++ {
+			m := reflect.Indirect(v.Index(n))
+			row := make([]string, len(headers))
+			for c, h := range headers {
+				val := m.MapIndex(reflect.ValueOf(h).Convert(elem.Key()))
+				if val.IsValid() {
+					row[c] = cell(val)
+				}
+			}
+			rows = append(rows, row)
+		}
+		return headers, rows, nil
+
+	case elem.Kind() == reflect.Struct:
+		var fields []int
+		var headers []string
+		for n := 0; n < elem.NumField(); n++ {
+			f := elem.Field(n)
+			tag := f.Tag.Get("table")
+			if !f.IsExported() || tag == "-" {
+				continue
+			}
+			fields = append(fields, n)
+			if tag == "" {
+				tag = f.Name
+			}
+			headers = append(headers, tag)
+		}
+		if t.Headers != nil {
+			headers = t.Headers
+		}
+		for n := 0; n < v.Len(); n++ {
+			s := reflect.Indirect(v.Index(n))
+			if !s.IsValid() {
+				rows = append(rows, []string{})
+				continue
+			}
+			row := make([]string, len(fields))
+			for c, f := range fields {
+				row[c] = cell(s.Field(f))
+			}
+			rows = append(rows, row)
+		}
+		return headers, rows, nil
+
+	}
+	return nil, nil, fmt.Errorf("cannot render %T as table", data)
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleTabled() {
+	out, err := to.Tabled([][]string{
+		{"Name", "Lang"},
+		{"rwxrob", "Go"},
+		{"someone", "Bash"},
+	})
+	fmt.Println(err)
+	fmt.Println(out)
+	// Output:
+	// <nil>
+	// ┌─────────┬──────┐
+	// │ Name    │ Lang │
+	// │ rwxrob  │ Go   │
+	// │ someone │ Bash │
+	// └─────────┴──────┘
+}
+
+func ExampleTable_Render_structs() {
+	type Item struct {
+		Name   string
+		Count  int     `table:"Qty"`
+		Price  float64 `table:"-"`
+		hidden bool
+	}
+	items := []Item{{"apple", 3, 0.5, true}, {"kiwi", 12, 0.2, false}}
+	out, _ := to.Table{
+		Style: to.ASCIITable,
+		Align: []to.Align{to.AlignLeft, to.AlignRight},
+	}.Render(items)
+	fmt.Println(out)
+	// Output:
+	// +-------+-----+
+	// | Name  | Qty |
+	// +-------+-----+
+	// | apple |   3 |
+	// | kiwi  |  12 |
+	// +-------+-----+
+}
+
+func ExampleTable_Render_maps() {
+	data := []map[string]any{
+		{"name": "rwxrob", "lang": "Go", "year": 2022},
+		{"name": "someone", "year": nil},
+	}
+	out, _ := to.Table{Style: to.PlainTable}.Render(data)
+	fmt.Println(out)
+	out, _ = to.Table{
+		Style:   to.PlainTable,
+		Headers: []string{"year", "name"},
+	}.Render(data)
+	fmt.Println(out)
+	// Output:
+	// lang   name      year
+	// Go     rwxrob    2022
+	//        someone
+	// year   name
+	// 2022   rwxrob
+	//        someone
+}
+
+func ExampleTable_Render_markdown() {
+	out, _ := to.Table{
+		Style:   to.MarkdownTable,
+		Headers: []string{"Flag", "Default", "Description"},
+		Align:   []to.Align{to.AlignLeft, to.AlignCenter, to.AlignRight},
+	}.Render([][]any{
+		{"-v", true, "verbose"},
+		{"-o", "a|b", "output"},
+	})
+	fmt.Println(out)
+	// Output:
+	// | Flag | Default | Description |
+	// |------|:-------:|------------:|
+	// | -v   |  true   |     verbose |
+	// | -o   |  a\|b   |      output |
+}
+
+func ExampleTable_Render_markdownNoHeaders() {
+	out, _ := to.Table{Style: to.MarkdownTable}.Render([][]string{
+		{"a", "one\ntwo"},
+		{"b", "three"},
+	})
+	fmt.Println(out)
+	// Output:
+	// |   |            |
+	// |---|------------|
+	// | a | one<br>two |
+	// | b | three      |
+}
+
+func ExampleTable_Render_wrapped() {
+	out, _ := to.Table{
+		Style:    to.BoxTable,
+		MaxWidth: []int{0, 12},
+	}.Render([][]string{
+		{"one", "a fairly long description here"},
+		{"two", "short"},
+	})
+	fmt.Println(out)
+	// Output:
+	// ┌─────┬─────────────┐
+	// │ one │ a fairly    │
+	// │     │ long        │
+	// │     │ description │
+	// │     │ here        │
+	// │ two │ short       │
+	// └─────┴─────────────┘
+}
+
+func ExampleTable_Render_truncated() {
+	out, _ := to.Table{
+		Style:    to.BoxTable,
+		Headers:  []string{"Key", "Value"},
+		MaxWidth: []int{0, 8},
+		Truncate: true,
+	}.Render([][]string{
+		{"color", "\033[31ma red value\033[0m"},
+		{"plain", "something long"},
+	})
+	fmt.Printf("%q\n", out)
+	// Output:
+	// "┌───────┬──────────┐\n│ Key   │ Value    │\n├───────┼──────────┤\n│ color │ \x1b[31ma red v…\x1b[0m │\n│ plain │ somethi… │\n└───────┴──────────┘"
+}
+
+func ExampleTable_Render_error() {
+	_, err := to.Tabled(42)
+	fmt.Println(err)
+	_, err = to.Tabled([]int{1, 2})
+	fmt.Println(err)
+	// Output:
+	// cannot render int as table
+	// cannot render []int as table
+}
+
+func ExampleTable_Render_wide() {
+	out, _ := to.Tabled([][]string{{"日本語", "ok"}, {"abc", "💚"}})
+	fmt.Println(out)
+	// Output:
+	// ┌────────┬────┐
+	// │ 日本語 │ ok │
+	// │ abc    │ 💚 │
+	// └────────┴────┘
+}
+
+func ExampleTable_Render_wideMaxWidth() {
+	data := [][]string{{"日本語", "ok"}, {"日本 語です", "x"}}
+	out, _ := to.Table{Style: to.BoxTable, MaxWidth: []int{4}, Truncate: true}.Render(data)
+	fmt.Println(out)
+	out, _ = to.Table{Style: to.BoxTable, MaxWidth: []int{4}}.Render(data)
+	fmt.Println(out)
+	// Output:
+	// ┌─────┬────┐
+	// │ 日… │ ok │
+	// │ 日… │ x  │
+	// └─────┴────┘
+	// ┌──────┬────┐
+	// │ 日…  │ ok │
+	// │ 日本 │ x  │
+	// │ 語…  │    │
+	// └──────┴────┘
+}
@@ -163,11 +163,12 @@ func Lines(in any) []string {
 // number of spaces. Carriage returns are stripped (if found) as
 // a side-effect.
 func Indented(in string, indent int) string {
-	var buf string
+	var buf strings.Builder
+	pre := strings.Repeat(" ", indent)
 	for _, line := range Lines(in) {
-		buf += fmt.Sprintln(strings.Repeat(" ", indent) + line)
+		buf.WriteString(pre + line + "\n")
 	}
-	return buf
+	return buf.String()
 }
 
 // IndentWrapped adds the specified number of spaces to the beginning of
@@ -286,14 +287,18 @@ func Words(it string) string {
 // unicode.IsSpace and does not include control characters. Anything
 // that is not unicode.IsSpace or unicode.IsGraphic will be ignored in
 // the column count. Any terminal escapes that begin with \033[ will
-// also be kept automatically out of calculations. See Unescaped.
+// also be kept automatically out of calculations. See Unescaped. Input
+// with no words at all returns an empty string.
 func Wrapped(it string, width int) (string, int) {
 	words := qstack.Fields(it)
+	if words.Len == 0 {
+		return "", 0
+	}
 	if width < 1 {
 		return strings.Join(words.Items(), " "), words.Len
 	}
 	var curwidth int
-	var wrapped string
+	var wrapped strings.Builder
 	var line []string
 	for words.Scan() {
 		cur := words.Current()
@@ -304,7 +309,7 @@ func Wrapped(it string, width int) (string, int) {
 			continue
 		}
 		if curwidth+count+1 > width {
-			wrapped += strings.Join(line, " ") + "\n"
+			wrapped.WriteString(strings.Join(line, " ") + "\n")
 			curwidth = count
 			line = []string{cur}
 			continue
@@ -312,8 +317,8 @@ func Wrapped(it string, width int) (string, int) {
 		line = append(line, cur)
 		curwidth += RuneCount(cur) + 1
 	}
-	wrapped += strings.Join(line, " ")
-	return wrapped, words.Len
+	wrapped.WriteString(strings.Join(line, " "))
+	return wrapped.String(), words.Len
 }
 
 // MergedMaps combines the maps with "last wins" priority. Always
@@ -373,96 +378,124 @@ func StopWatch(dur time.Duration) string {
 // than Sprintf("%q") since that escapes several other things.
 func EscReturns[T string | []byte | []rune](in T) string {
 	runes := []rune(string(in))
-	var out string
+	var out strings.Builder
 	for _, r := range runes {
 		switch r {
 		case '\r':
-			out += "\\r"
+			out.WriteString("\\r")
 		case '\n':
-			out += "\\n"
+			out.WriteString("\\n")
 		default:
-			out += string(r)
+			out.WriteRune(r)
 		}
 	}
-	return string(out)
+	return out.String()
 }
 
 // UnEscReturns changes any escaped carriage returns or line returns into
 // their actual values.
 func UnEscReturns[T string | []byte | []rune](in T) string {
 	runes := []rune(string(in))
-	var out string
+	var out strings.Builder
 	for n := 0; n < len(runes); n++ {
 		if runes[n] == '\\' && runes[n+1] == 'r' {
-			out += "\r"
+			out.WriteString("\r")
 			n++
 			continue
 		}
 		if runes[n] == '\\' && runes[n+1] == 'n' {
-			out += "\n"
+			out.WriteString("\n")
 			n++
 			continue
 		}
-		out += string(runes[n])
+		out.WriteRune(runes[n])
 	}
-	return string(out)
+	return out.String()
 }
 
 // HTTPS simply adds the prefix "https://" if not found. Useful for
-// allowing non-prefixed URLs and later converting them.
+// allowing non-prefixed URLs and later converting them. Existing
+// http:// and https:// prefixes are detected without regard to case
+// and replaced. Protocol relative (//host) URLs are also detected. See
+// NormalizedURL for more.
 func HTTPS(url string) string {
-	if len(url) < 8 || url[0:8] != "https://" {
-		return "https://" + url
+	switch {
+	case len(url) >= 8 && strings.EqualFold(url[0:8], "https://"):
+		return "https://" + url[8:]
+	case len(url) >= 7 && strings.EqualFold(url[0:7], "http://"):
+		return "https://" + url[7:]
+	case strings.HasPrefix(url, "//"):
+		return "https:" + url
 	}
-	return url
+	return "https://" + url
 }
 
-// CrunchSpace crunches all unicode.IsSpace into a single space. It does
-// not trim. See TrimCrunchSpace.
-func CrunchSpace(in string) string {
+// Crunch replaces every contiguous run of runes for which pred returns
+// true with the replacement string (which may be empty). It does not
+// trim. See CrunchSpace and CrunchFiltered.
+func Crunch(in string, pred func(r rune) bool, repl string) string {
+	return CrunchFiltered(in, pred, nil, repl)
+}
+
+// Filter returns only the runes for which keep returns true. See
+// Visible.
+func Filter(in string, keep func(r rune) bool) string {
 	runes := make([]rune, 0)
 	s := scanner.New(in)
-	var inspace bool
 	for s.Scan() {
 		r := s.Rune()
-		if unicode.IsSpace(r) {
-			if inspace {
+		if keep(r) {
+			runes = append(runes, r)
+		}
+	}
+	return string(runes)
+}
+
+// CrunchFiltered combines Crunch and Filter into a single pass. Runes
+// for which pred returns true are crunched first. Of those remaining,
+// any for which keep returns false are dropped without interrupting the
+// current run so that runs separated only by dropped runes are still
+// crunched together. A nil keep keeps everything. See
+// CrunchSpaceVisible.
+func CrunchFiltered(in string, pred, keep func(r rune) bool, repl string) string {
+	runes := make([]rune, 0)
+	s := scanner.New(in)
+	var inrun bool
+	for s.Scan() {
+		r := s.Rune()
+		if pred(r) {
+			if inrun {
 				continue
 			}
-			runes = append(runes, ' ')
-			inspace = true
+			runes = append(runes, []rune(repl)...)
+			inrun = true
+			continue
+		}
+		if keep != nil && !keep(r) {
 			continue
 		}
-		inspace = false
+		inrun = false
 		runes = append(runes, r)
 	}
 	return string(runes)
 }
 
+// CrunchSpace crunches all unicode.IsSpace into a single space. It does
+// not trim. See TrimCrunchSpace.
+func CrunchSpace(in string) string { return Crunch(in, unicode.IsSpace, " ") }
+
 // CrunchSpaceVisible crunches all unicode.IsSpace into a single space
 // and filters out anything that is not unicode.IsPrint. It does not
-// trim. See TrimCrunchSpaceVisible. Note that this requires three
-// passes through the string in order to resolve any white space that
-// might have been separated by escape and other characters.
+// trim. See TrimCrunchSpaceVisible. White space separated only by
+// filtered runes is still crunched into a single space.
 func CrunchSpaceVisible(in string) string {
-	in = CrunchSpace(in)
-	in = Visible(in)
-	in = CrunchSpace(in)
-	return in
+	return CrunchFiltered(in, unicode.IsSpace, unicode.IsPrint, " ")
 }
 
-// Visible filters out any rune that is not unicode.IsPrint().
-func Visible(in string) string {
-	runes := make([]rune, 0)
-	s := scanner.New(in)
-	for s.Scan() {
-		r := s.Rune()
-		if unicode.IsPrint(r) {
-			runes = append(runes, r)
-		}
-	}
-	return string(runes)
-}
+// Visible filters out any rune that is not unicode.IsPrint(). Note
+// that only the escape rune of any terminal escape sequence is removed.
+// See Unescaped.
+func Visible(in string) string { return Filter(in, unicode.IsPrint) }
 
 // TrimVisible removes anything but unicode.IsPrint and then trims. It
 // does not crunch spaces, however.
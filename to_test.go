@@ -6,6 +6,7 @@ package to_test
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/rwxrob/fn"
 	"github.com/rwxrob/fn/each"
@@ -241,9 +242,15 @@ func ExampleUnEscReturns() {
 func ExampleHTTPS() {
 	fmt.Println(to.HTTPS(`https://rwx.gg`))
 	fmt.Println(to.HTTPS(`rwx.gg`))
+	fmt.Println(to.HTTPS(`http://rwx.gg`))
+	fmt.Println(to.HTTPS(`HTTPS://rwx.gg`))
+	fmt.Println(to.HTTPS(`//rwx.gg`))
 	// Output:
 	// https://rwx.gg
 	// https://rwx.gg
+	// https://rwx.gg
+	// https://rwx.gg
+	// https://rwx.gg
 }
 
 func ExampleRuneCount() {
@@ -340,3 +347,38 @@ func ExampleTrimCrunchSpaceVisible() {
 	// "here is some"
 
 }
+
+func ExampleCrunch() {
+	dashes := func(r rune) bool { return r == '-' || r == '_' }
+	fmt.Printf("%q\n", to.Crunch(`some---thing__here`, dashes, "-"))
+	fmt.Printf("%q\n", to.Crunch(`some...thing!?`, unicode.IsPunct, ""))
+	fmt.Printf("%q\n", to.Crunch("here  is\t\nsome", unicode.IsSpace, "_"))
+	// Output:
+	// "some-thing-here"
+	// "something"
+	// "here_is_some"
+}
+
+func ExampleFilter() {
+	fmt.Printf("%q\n", to.Filter(`a1b2c3`, unicode.IsLetter))
+	fmt.Printf("%q\n", to.Filter("here\033 is\a", unicode.IsPrint))
+	// Output:
+	// "abc"
+	// "here is"
+}
+
+func ExampleCrunchFiltered() {
+	fmt.Printf("%q\n", to.CrunchFiltered("a -\033- b", unicode.IsPunct, unicode.IsPrint, "-"))
+	fmt.Printf("%q\n", to.CrunchFiltered("a \033 \a b", unicode.IsSpace, unicode.IsPrint, " "))
+	// Output:
+	// "a - b"
+	// "a b"
+}
+
+func ExampleCrunchSpaceVisible() {
+	fmt.Printf("%q\n", to.CrunchSpaceVisible(" here  \033  is\nsome "))
+	fmt.Printf("%q\n", to.CrunchSpaceVisible("\033 here\u00a0\u00a0is"))
+	// Output:
+	// " here is some "
+	// " here is"
+}
@@ -0,0 +1,190 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TruncPos is the position of the ellipsis when truncating.
+type TruncPos int
+
+const (
+	TruncEnd TruncPos = iota
+	TruncStart
+	TruncMiddle
+)
+
+// DefaultEllipsis is used by Truncated.
+var DefaultEllipsis = "…"
+
+// Truncator contains the configuration used to Truncate a string to
+// fit within a given Width (see DisplayWidth). The Ellipsis (which counts
+// against the Width) is placed at the end, start, or middle depending
+// on Pos. If Words is true the cut is moved back to the nearest word
+// boundary (white space) when there is one.
+type Truncator struct {
+	Width    int
+	Pos      TruncPos
+	Ellipsis string
+	Words    bool
+}
+
+// Truncated is shorthand for truncating the end of the string to fit
+// within width using the DefaultEllipsis (see Truncator.Truncate).
+func Truncated(in string, width int) string {
+	return Truncator{Width: width, Ellipsis: DefaultEllipsis}.Truncate(in)
+}
+
+// truncTok is either a single rune or an entire terminal escape
+// sequence. Only visible runes are counted (see RuneWidth).
+type truncTok struct {
+	text  string
+	esc   bool
+	count int
+}
+
+func truncToks(in string) []truncTok {
+	var toks []truncTok
+	runes := []rune(in)
+	for n := 0; n < len(runes); n++ {
+		r := runes[n]
+		if r == '\033' && n+1 < len(runes) && runes[n+1] == '[' {
+			start := n
+			for n < len(runes) && runes[n] != 'm' {
+				n++
+			}
+			if n == len(runes) {
+				n--
+			}
+			toks = append(toks, truncTok{text: string(runes[start : n+1]), esc: true})
+			continue
+		}
+		toks = append(toks, truncTok{text: string(r), count: RuneWidth(r)})
+	}
+	return toks
+}
+
+// isspace returns true if the token is a single white space rune.
+func (t truncTok) isspace() bool {
+	return !t.esc && len(t.text) > 0 && unicode.IsSpace([]rune(t.text)[0])
+}
+
+// Truncate returns the string cut to fit within Width including the
+// Ellipsis. Strings that already fit are returned unchanged. Terminal
+// escape sequences are never cut and do not count toward the width.
+// Escapes within the part that is removed are kept (so the remaining
+// text has the same style) and a reset (\033[0m) is added to the end if
+// the last escape kept is not already a reset. If the Width is too
+// small for even the Ellipsis, the Ellipsis is itself cut to fit. A
+// Width of zero or less always returns an empty string.
+func (t Truncator) Truncate(in string) string {
+	if t.Width <= 0 {
+		return ""
+	}
+	toks := truncToks(in)
+	var total int
+	for _, tok := range toks {
+		total += tok.count
+	}
+	if total <= t.Width {
+		return in
+	}
+
+	avail := t.Width - DisplayWidth(t.Ellipsis)
+	if avail < 0 {
+		var out strings.Builder
+		var w int
+		for _, r := range t.Ellipsis {
+			if w += RuneWidth(r); w > t.Width {
+				break
+			}
+			out.WriteRune(r)
+		}
+		return out.String()
+	}
+
+	var head, tail int // number of columns to keep
+	switch t.Pos {
+	case TruncStart:
+		tail = avail
+	case TruncMiddle:
+		head = (avail + 1) / 2
+		tail = avail / 2
+	default:
+		head = avail
+	}
+
+	// h is the index of the first token after the head
+	var h, count int
+	for ; h < len(toks); h++ {
+		if toks[h].count > 0 && count+toks[h].count > head {
+			break
+		}
+		count += toks[h].count
+	}
+	// move back to a word boundary and trim any trailing space
+	if t.Words && head > 0 && h < len(toks) && !toks[h].isspace() {
+		for b := h - 1; b > 0; b-- {
+			if toks[b].isspace() {
+				h = b
+				break
+			}
+		}
+	}
+	for h > 0 && toks[h-1].isspace() {
+		h--
+	}
+
+	// s is the index of the first token of the tail
+	s := len(toks)
+	count = 0
+	for s > h {
+		if toks[s-1].count > 0 && count+toks[s-1].count > tail {
+			break
+		}
+		s--
+		count += toks[s].count
+	}
+	if t.Words && tail > 0 && s > h && !toks[s-1].isspace() {
+		for b := s; b < len(toks); b++ {
+			if toks[b].isspace() {
+				s = b
+				break
+			}
+		}
+	}
+	for s < len(toks) && toks[s].isspace() {
+		s++
+	}
+
+	var out strings.Builder
+	var lastesc string
+	for _, tok := range toks[:h] {
+		out.WriteString(tok.text)
+		if tok.esc {
+			lastesc = tok.text
+		}
+	}
+	out.WriteString(t.Ellipsis)
+	if s < len(toks) {
+		for _, tok := range toks[h:s] {
+			if tok.esc {
+				out.WriteString(tok.text)
+				lastesc = tok.text
+			}
+		}
+		for _, tok := range toks[s:] {
+			out.WriteString(tok.text)
+			if tok.esc {
+				lastesc = tok.text
+			}
+		}
+	}
+	if lastesc != "" && lastesc != "\033[0m" && lastesc != "\033[m" {
+		out.WriteString("\033[0m")
+	}
+	return out.String()
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleTruncated() {
+	fmt.Println(to.Truncated("some thing here", 10))
+	fmt.Println(to.Truncated("some thing", 10))
+	fmt.Println(to.Truncated("💢💚💢💚💢💚", 4))
+	fmt.Println(to.Truncated("💢💚💢💚💢💚", 5))
+	fmt.Println(to.Truncated("日本語", 1))
+	fmt.Printf("%q\n", to.Truncated("\033[31mred\033[0m and \033[34mblue\033[0m", 10))
+	fmt.Printf("%q\n", to.Truncated("\033[31mall red thing", 5))
+	// Output:
+	// some thin…
+	// some thing
+	// 💢…
+	// 💢💚…
+	// …
+	// "\x1b[31mred\x1b[0m and \x1b[34mb…\x1b[0m"
+	// "\x1b[31mall…\x1b[0m"
+}
+
+func ExampleTruncator_Truncate() {
+	in := "/home/rwxrob/some/deeply/nested/path"
+	fmt.Println(to.Truncator{Width: 16, Pos: to.TruncStart, Ellipsis: "..."}.Truncate(in))
+	fmt.Println(to.Truncator{Width: 16, Pos: to.TruncMiddle, Ellipsis: "..."}.Truncate(in))
+	fmt.Println(to.Truncator{Width: 16, Pos: to.TruncEnd, Ellipsis: "..."}.Truncate(in))
+	fmt.Println(to.Truncator{Width: 2, Ellipsis: "..."}.Truncate(in))
+	fmt.Printf("%q\n", to.Truncated("abc", 0))
+	fmt.Printf("%q\n", to.Truncated("abc", -1))
+	// Output:
+	// ...y/nested/path
+	// /home/r...d/path
+	// /home/rwxrob/...
+	// ..
+	// ""
+	// ""
+}
+
+func ExampleTruncator_Truncate_words() {
+	in := "The quick brown fox jumps over the lazy dog"
+	fmt.Printf("%q\n", to.Truncator{Width: 18, Ellipsis: "…", Words: true}.Truncate(in))
+	fmt.Printf("%q\n", to.Truncator{Width: 18, Ellipsis: "…", Pos: to.TruncStart, Words: true}.Truncate(in))
+	fmt.Printf("%q\n", to.Truncator{Width: 18, Ellipsis: "…", Pos: to.TruncMiddle, Words: true}.Truncate(in))
+	fmt.Printf("%q\n", to.Truncator{Width: 5, Ellipsis: "…", Words: true}.Truncate("Supercalifragilistic"))
+	// Output:
+	// "The quick brown…"
+	// "…over the lazy dog"
+	// "The quick…lazy dog"
+	// "Supe…"
+}
+
+func ExampleTruncator_Truncate_escapes() {
+	in := "\033[1mbold\033[0m then \033[32mgreen to the end\033[0m"
+	fmt.Printf("%q\n", to.Truncator{Width: 12, Pos: to.TruncStart, Ellipsis: "…"}.Truncate(in))
+	fmt.Printf("%q\n", to.Truncator{Width: 12, Pos: to.TruncMiddle, Ellipsis: "…"}.Truncate(in))
+	// Output:
+	// "\x1b[1m…\x1b[0m\x1b[32mto the end\x1b[0m"
+	// "\x1b[1mbold\x1b[0m t…\x1b[32me end\x1b[0m"
+}
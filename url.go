@@ -0,0 +1,174 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/idna"
+)
+
+// defaultPorts contains the port for each scheme that is dropped by
+// NormalizedURL since it is implied.
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ws":    "80",
+	"wss":   "443",
+	"ftp":   "21",
+}
+
+var hasscheme = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+var hostport = regexp.MustCompile(`^([^/?#:]+):[0-9]+(?:[/?#]|$)`)
+
+// isHostPort returns true if the input begins with what can only be
+// a host and port (example.com:8080, localhost:80, 10.0.0.1:22) rather
+// than a scheme followed by digits (tel:5551234).
+func isHostPort(in string) bool {
+	m := hostport.FindStringSubmatch(in)
+	if m == nil {
+		return false
+	}
+	host := m[1]
+	return strings.Contains(host, ".") || strings.EqualFold(host, "localhost") ||
+		net.ParseIP(host) != nil
+}
+
+// URLNormalizer contains the configuration used to Normalize a URL.
+// If Upgrade is true http is upgraded to https. Ports contains the
+// default port for each scheme that is removed since it is implied (nil
+// uses http, https, ws, wss, and ftp).
+type URLNormalizer struct {
+	Upgrade bool
+	Ports   map[string]string
+}
+
+// NormalizedURL is shorthand for URLNormalizer{Upgrade: upgrade}.Normalize.
+func NormalizedURL(in string, upgrade bool) (string, error) {
+	return URLNormalizer{Upgrade: upgrade}.Normalize(in)
+}
+
+// Normalize returns a normalized version of the URL string or an error
+// if it is empty, has no host, or cannot be parsed by net/url. The
+// following are normalized:
+//
+//   - missing scheme (bare host, host:port, or //host) becomes https
+//     (host:port only when the host has a dot, is localhost, or is an
+//     IP address since it is otherwise a scheme)
+//   - scheme is detected and lowercased (HTTPS:// -> https://)
+//   - http is upgraded to https (if Upgrade is true)
+//   - host is lowercased and any non-ASCII labels are converted to
+//     punycode (other labels are left as is, underscores and all)
+//   - default ports (see Ports) of the original scheme are removed
+//   - dot segments (. and ..) are removed from the path
+//   - empty path with a host becomes /
+//   - query parameters are sorted by key
+//
+// Opaque URLs (mailto:foo@example.com) only have their scheme
+// lowercased. See HTTPS for a simpler alternative.
+func (n URLNormalizer) Normalize(in string) (string, error) {
+	in = strings.TrimSpace(in)
+	if in == "" {
+		return "", &url.Error{Op: "normalize", URL: in, Err: errors.New("empty URL")}
+	}
+	ports := n.Ports
+	if ports == nil {
+		ports = defaultPorts
+	}
+
+	switch {
+	case strings.HasPrefix(in, "//"):
+		in = "https:" + in
+	case isHostPort(in) || !hasscheme.MatchString(in):
+		in = "https://" + in
+	}
+
+	u, err := url.Parse(in)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := u.Scheme
+	if n.Upgrade && u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+
+	if u.Opaque != "" {
+		return u.String(), nil
+	}
+
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if host == "" {
+		return "", &url.Error{Op: "normalize", URL: in, Err: errors.New("missing host")}
+	}
+	if net.ParseIP(host) == nil && strings.IndexFunc(host, func(r rune) bool { return r > unicode.MaxASCII }) >= 0 {
+		host, err = idna.Punycode.ToASCII(NFC(host))
+		if err != nil {
+			return "", &url.Error{Op: "normalize", URL: in, Err: err}
+		}
+	}
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port != "" && ports[scheme] != port {
+		host += ":" + port
+	}
+	u.Host = host
+
+	p := RemovedDotSegments(u.EscapedPath())
+	if p == "" && u.Host != "" {
+		p = "/"
+	}
+	u.Path, err = url.PathUnescape(p)
+	if err != nil {
+		return "", &url.Error{Op: "normalize", URL: in, Err: err}
+	}
+	u.RawPath = p
+
+	if u.RawQuery != "" {
+		q, err := url.ParseQuery(u.RawQuery)
+		if err != nil {
+			return "", &url.Error{Op: "normalize", URL: in, Err: err}
+		}
+		u.RawQuery = q.Encode()
+	}
+	u.ForceQuery = false
+
+	return u.String(), nil
+}
+
+// RemovedDotSegments returns the path with all . and .. segments
+// removed as described in RFC 3986 section 5.2.4. Unlike path.Clean,
+// trailing and duplicate slashes are preserved.
+func RemovedDotSegments(in string) string {
+	var out []string
+	segs := strings.Split(in, "/")
+	for n, seg := range segs {
+		last := n == len(segs)-1
+		switch seg {
+		case ".":
+			if last {
+				out = append(out, "")
+			}
+		case "..":
+			if len(out) > 1 || (len(out) == 1 && out[0] != "") {
+				out = out[:len(out)-1]
+			}
+			if last {
+				out = append(out, "")
+			}
+		default:
+			out = append(out, seg)
+		}
+	}
+	if strings.HasPrefix(in, "/") && (len(out) == 0 || out[0] != "") {
+		out = append([]string{""}, out...)
+	}
+	return strings.Join(out, "/")
+}
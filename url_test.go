@@ -0,0 +1,89 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+
+	"github.com/rwxrob/to"
+)
+
+func ExampleNormalizedURL() {
+	for _, in := range []string{
+		`rwx.gg`,
+		`//rwx.gg/some`,
+		`localhost:8080/some`,
+		`HTTPS://RWX.gg:443/a/./b/../c/?z=1&a=2&m=3`,
+		`http://rwx.gg:80`,
+		`http://rwx.gg:8080/`,
+		`https://Bücher.example/`,
+		`http://my_host.local/`,
+		`https://user:pass@[::1]:443/x`,
+		`MAILTO:rob@rwx.gg`,
+		`tel:5551234`,
+		`URN:isbn:0451450523`,
+		`127.0.0.1:8080`,
+	} {
+		out, err := to.NormalizedURL(in, false)
+		fmt.Println(out, err)
+	}
+	// Output:
+	// https://rwx.gg/ <nil>
+	// https://rwx.gg/some <nil>
+	// https://localhost:8080/some <nil>
+	// https://rwx.gg/a/c/?a=2&m=3&z=1 <nil>
+	// http://rwx.gg/ <nil>
+	// http://rwx.gg:8080/ <nil>
+	// https://xn--bcher-kva.example/ <nil>
+	// http://my_host.local/ <nil>
+	// https://user:pass@[::1]/x <nil>
+	// mailto:rob@rwx.gg <nil>
+	// tel:5551234 <nil>
+	// urn:isbn:0451450523 <nil>
+	// https://127.0.0.1:8080/ <nil>
+}
+
+func ExampleNormalizedURL_upgrade() {
+	out, err := to.NormalizedURL(`http://rwx.gg:80/some`, true)
+	fmt.Println(out, err)
+	// Output:
+	// https://rwx.gg/some <nil>
+}
+
+func ExampleNormalizedURL_error() {
+	_, err := to.NormalizedURL(`https://rwx.gg:bork/`, false)
+	fmt.Println(err != nil)
+	_, err = to.NormalizedURL(`https://rwx.gg/?a=%zz`, false)
+	fmt.Println(err != nil)
+	_, err = to.NormalizedURL(``, false)
+	fmt.Println(err)
+	_, err = to.NormalizedURL(`file:///etc/hosts`, false)
+	fmt.Println(err)
+	// Output:
+	// true
+	// true
+	// normalize "": empty URL
+	// normalize "file:///etc/hosts": missing host
+}
+
+func ExampleURLNormalizer_Normalize() {
+	n := to.URLNormalizer{Upgrade: true, Ports: map[string]string{"https": "8443"}}
+	fmt.Println(n.Normalize(`HTTPS://rwx.gg:8443/`))
+	fmt.Println(n.Normalize(`https://rwx.gg:443/`))
+	// Output:
+	// https://rwx.gg/ <nil>
+	// https://rwx.gg:443/ <nil>
+}
+
+func ExampleRemovedDotSegments() {
+	fmt.Println(to.RemovedDotSegments(`/a/b/c/./../../g`))
+	fmt.Println(to.RemovedDotSegments(`mid/content=5/../6`))
+	fmt.Println(to.RemovedDotSegments(`/a//b/../c/`))
+	fmt.Println(to.RemovedDotSegments(`/..`))
+	// Output:
+	// /a/g
+	// mid/6
+	// /a//c/
+	// /
+}
@@ -0,0 +1,320 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The writers in this file are streaming equivalents of the string
+// converters of the same name and produce identical output when
+// everything written to them is passed to the string version instead.
+// They can be stacked in front of any io.Writer (os.Stdout, for
+// example) and only ever keep a single line, word, or rune in memory.
+// Each must be closed (outermost first when stacked) to flush whatever
+// remains. Closing never closes the underlying writer.
+
+// liner buffers written bytes until a full line (\r?\n) has arrived and
+// then passes the line (without the line ending) to emit, the same way
+// Lines would split it.
+type liner struct {
+	buf  []byte
+	emit func(line []byte) error
+}
+
+func (l *liner) write(p []byte) error {
+	for {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			l.buf = append(l.buf, p...)
+			return nil
+		}
+		l.buf = append(l.buf, p[:i]...)
+		p = p[i+1:]
+		if err := l.emit(bytes.TrimSuffix(l.buf, []byte{'\r'})); err != nil {
+			return err
+		}
+		l.buf = l.buf[:0]
+	}
+}
+
+func (l *liner) close() error {
+	if len(l.buf) == 0 {
+		return nil
+	}
+	err := l.emit(bytes.TrimSuffix(l.buf, []byte{'\r'}))
+	l.buf = l.buf[:0]
+	return err
+}
+
+// runer buffers any incomplete UTF-8 sequence at the end of a write so
+// that emit is only ever called with whole runes.
+type runer struct {
+	partial []byte
+	emit    func(r rune) error
+}
+
+func (u *runer) write(p []byte) error {
+	if len(u.partial) > 0 {
+		p = append(u.partial, p...)
+		u.partial = nil
+	}
+	for len(p) > 0 {
+		if !utf8.FullRune(p) {
+			u.partial = append([]byte{}, p...)
+			return nil
+		}
+		r, size := utf8.DecodeRune(p)
+		p = p[size:]
+		if err := u.emit(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *runer) close() error {
+	p := u.partial
+	u.partial = nil
+	for len(p) > 0 {
+		r, size := utf8.DecodeRune(p)
+		p = p[size:]
+		if err := u.emit(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PrefixWriter is the streaming equivalent of Prefixed.
+type PrefixWriter struct {
+	W      io.Writer
+	Prefix string
+	lines  liner
+	begun  bool
+}
+
+// NewPrefixWriter returns a new PrefixWriter writing to w.
+func NewPrefixWriter(w io.Writer, pre string) *PrefixWriter {
+	p := &PrefixWriter{W: w, Prefix: pre}
+	p.lines.emit = p.emit
+	return p
+}
+
+func (p *PrefixWriter) emit(line []byte) error {
+	var buf []byte
+	if p.begun {
+		buf = append(buf, '\n')
+	}
+	p.begun = true
+	buf = append(buf, p.Prefix...)
+	buf = append(buf, line...)
+	_, err := p.W.Write(buf)
+	return err
+}
+
+// Write fulfills io.Writer.
+func (p *PrefixWriter) Write(b []byte) (int, error) {
+	if err := p.lines.write(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close writes any remaining partial line.
+func (p *PrefixWriter) Close() error { return p.lines.close() }
+
+// IndentWriter is the streaming equivalent of Indented.
+type IndentWriter struct {
+	W      io.Writer
+	Indent int
+	lines  liner
+}
+
+// NewIndentWriter returns a new IndentWriter writing to w.
+func NewIndentWriter(w io.Writer, indent int) *IndentWriter {
+	i := &IndentWriter{W: w, Indent: indent}
+	i.lines.emit = i.emit
+	return i
+}
+
+func (i *IndentWriter) emit(line []byte) error {
+	buf := make([]byte, 0, i.Indent+len(line)+1)
+	buf = append(buf, strings.Repeat(" ", i.Indent)...)
+	buf = append(buf, line...)
+	buf = append(buf, '\n')
+	_, err := i.W.Write(buf)
+	return err
+}
+
+// Write fulfills io.Writer.
+func (i *IndentWriter) Write(b []byte) (int, error) {
+	if err := i.lines.write(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close writes any remaining partial line (with a line return).
+func (i *IndentWriter) Close() error { return i.lines.close() }
+
+// WrapWriter is the streaming equivalent of Wrapped. Count contains the
+// number of words written so far.
+type WrapWriter struct {
+	W        io.Writer
+	Width    int
+	Count    int
+	word     []rune
+	curwidth int
+	runes    runer
+}
+
+// NewWrapWriter returns a new WrapWriter writing to w.
+func NewWrapWriter(w io.Writer, width int) *WrapWriter {
+	ww := &WrapWriter{W: w, Width: width}
+	ww.runes.emit = ww.emit
+	return ww
+}
+
+func (w *WrapWriter) emit(r rune) error {
+	if unicode.IsSpace(r) {
+		return w.flush()
+	}
+	w.word = append(w.word, r)
+	return nil
+}
+
+// flush writes the current word preceded by whatever separator Wrapped
+// would have put before it.
+func (w *WrapWriter) flush() error {
+	if len(w.word) == 0 {
+		return nil
+	}
+	word := string(w.word)
+	w.word = w.word[:0]
+	count := RuneCount(word)
+	var sep string
+	switch {
+	case w.Count == 0:
+		w.curwidth = count
+	case w.Width < 1:
+		sep = " "
+	case w.curwidth+count+1 > w.Width:
+		sep = "\n"
+		w.curwidth = count
+	default:
+		sep = " "
+		w.curwidth += count + 1
+	}
+	w.Count++
+	_, err := io.WriteString(w.W, sep+word)
+	return err
+}
+
+// Write fulfills io.Writer.
+func (w *WrapWriter) Write(b []byte) (int, error) {
+	if err := w.runes.write(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close writes the last word (if any).
+func (w *WrapWriter) Close() error {
+	if err := w.runes.close(); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+// CrunchWriter is the streaming equivalent of CrunchFiltered. A nil
+// Pred crunches nothing and a nil Keep keeps everything.
+type CrunchWriter struct {
+	W     io.Writer
+	Pred  func(r rune) bool
+	Keep  func(r rune) bool
+	Repl  string
+	inrun bool
+	runes runer
+}
+
+// NewCrunchWriter returns a new CrunchWriter writing to w.
+func NewCrunchWriter(w io.Writer, pred, keep func(r rune) bool, repl string) *CrunchWriter {
+	c := &CrunchWriter{W: w, Pred: pred, Keep: keep, Repl: repl}
+	c.runes.emit = c.emit
+	return c
+}
+
+// NewCrunchSpaceWriter returns a CrunchWriter equivalent to CrunchSpace.
+func NewCrunchSpaceWriter(w io.Writer) *CrunchWriter {
+	return NewCrunchWriter(w, unicode.IsSpace, nil, " ")
+}
+
+// NewVisibleWriter returns a CrunchWriter equivalent to Visible.
+func NewVisibleWriter(w io.Writer) *CrunchWriter {
+	return NewCrunchWriter(w, nil, unicode.IsPrint, "")
+}
+
+func (c *CrunchWriter) emit(r rune) error {
+	var err error
+	switch {
+	case c.Pred != nil && c.Pred(r):
+		if !c.inrun {
+			_, err = io.WriteString(c.W, c.Repl)
+			c.inrun = true
+		}
+	case c.Keep != nil && !c.Keep(r):
+	default:
+		c.inrun = false
+		_, err = io.WriteString(c.W, string(r))
+	}
+	return err
+}
+
+// Write fulfills io.Writer.
+func (c *CrunchWriter) Write(b []byte) (int, error) {
+	if err := c.runes.write(b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close writes any incomplete trailing rune.
+func (c *CrunchWriter) Close() error { return c.runes.close() }
+
+// EscReturnsWriter is the streaming equivalent of EscReturns.
+type EscReturnsWriter struct {
+	W io.Writer
+}
+
+// NewEscReturnsWriter returns a new EscReturnsWriter writing to w.
+func NewEscReturnsWriter(w io.Writer) *EscReturnsWriter {
+	return &EscReturnsWriter{W: w}
+}
+
+// Write fulfills io.Writer.
+func (e *EscReturnsWriter) Write(b []byte) (int, error) {
+	buf := make([]byte, 0, len(b))
+	for _, c := range b {
+		switch c {
+		case '\r':
+			buf = append(buf, '\\', 'r')
+		case '\n':
+			buf = append(buf, '\\', 'n')
+		default:
+			buf = append(buf, c)
+		}
+	}
+	if _, err := e.W.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close does nothing since nothing is ever buffered.
+func (e *EscReturnsWriter) Close() error { return nil }
@@ -0,0 +1,115 @@
+// Copyright 2022 Robert S. Muhlestein
+// SPDX-License-Identifier: Apache-2.0
+
+package to_test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/rwxrob/to"
+)
+
+// bytewise writes one byte at a time to prove that nothing depends on
+// how the writes are chunked.
+func bytewise(w io.WriteCloser, in string) {
+	for _, b := range []byte(in) {
+		w.Write([]byte{b})
+	}
+	w.Close()
+}
+
+func ExamplePrefixWriter() {
+	in := "some\r\nthing\n\nhere\n"
+	buf := new(strings.Builder)
+	bytewise(to.NewPrefixWriter(buf, "P  "), in)
+	fmt.Println(buf.String() == to.Prefixed(in, "P  "))
+	fmt.Printf("%q\n", buf)
+	// Output:
+	// true
+	// "P  some\nP  thing\nP  \nP  here"
+}
+
+func ExampleIndentWriter() {
+	in := "some\nthing"
+	buf := new(strings.Builder)
+	bytewise(to.NewIndentWriter(buf, 4), in)
+	fmt.Println(buf.String() == to.Indented(in, 4))
+	fmt.Print(buf)
+	// Output:
+	// true
+	//     some
+	//     thing
+}
+
+func ExampleWrapWriter() {
+	in := "Here is a \033[34mblüe\033[0m thing\n\tthat wraps 💢 nicely "
+	buf := new(strings.Builder)
+	w := to.NewWrapWriter(buf, 16)
+	bytewise(w, in)
+	out, count := to.Wrapped(in, 16)
+	fmt.Println(buf.String() == out, w.Count == count)
+	fmt.Printf("%q\n", buf)
+	// Output:
+	// true true
+	// "Here is a \x1b[34mblüe\x1b[0m\nthing that wraps\n💢 nicely"
+}
+
+func ExampleWrapWriter_stacked() {
+	p := to.NewPrefixWriter(os.Stdout, "// ")
+	w := to.NewWrapWriter(p, 20)
+	fmt.Fprint(w, "The quick brown fox jumps over the lazy dog.")
+	w.Close()
+	p.Close()
+	// Output:
+	// // The quick brown fox
+	// // jumps over the lazy
+	// // dog.
+}
+
+func ExampleCrunchWriter() {
+	in := " here  \033  is\nsome ünicode\a "
+	buf := new(strings.Builder)
+	bytewise(to.NewCrunchWriter(buf, unicode.IsSpace, unicode.IsPrint, " "), in)
+	fmt.Println(buf.String() == to.CrunchSpaceVisible(in))
+	fmt.Printf("%q\n", buf)
+	// Output:
+	// true
+	// " here is some ünicode "
+}
+
+func ExampleNewCrunchSpaceWriter() {
+	in := "here is\r\n some"
+	buf := new(strings.Builder)
+	bytewise(to.NewCrunchSpaceWriter(buf), in)
+	fmt.Println(buf.String() == to.CrunchSpace(in))
+	fmt.Printf("%q\n", buf)
+	// Output:
+	// true
+	// "here is some"
+}
+
+func ExampleNewVisibleWriter() {
+	in := "here\033 is\a 💚"
+	buf := new(strings.Builder)
+	bytewise(to.NewVisibleWriter(buf), in)
+	fmt.Println(buf.String() == to.Visible(in))
+	fmt.Printf("%q\n", buf)
+	// Output:
+	// true
+	// "here is 💚"
+}
+
+func ExampleEscReturnsWriter() {
+	in := "some\rthing\n"
+	buf := new(strings.Builder)
+	bytewise(to.NewEscReturnsWriter(buf), in)
+	fmt.Println(buf.String() == to.EscReturns(in))
+	fmt.Println(buf)
+	// Output:
+	// true
+	// some\rthing\n
+}